@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 var ErrNotFound = errors.New("not found")
@@ -17,6 +18,18 @@ type HostingerClient struct {
 	HTTPClient *http.Client
 	Token      string
 	Version    string
+
+	// dnsZoneLocks holds a *sync.Mutex per domain, see lockDNSZone
+	dnsZoneLocks sync.Map
+
+	// claimedVMs holds the IDs of initial VPSs adopted during this run, see
+	// claimInitialVirtualMachine
+	claimedVMsMu sync.Mutex
+	claimedVMs   map[int]bool
+
+	// scriptHashes caches the content hash of post-install scripts looked up
+	// during this run, see postInstallScriptHashForPlan
+	scriptHashes sync.Map
 }
 
 // NewHostingerClient initializes a new API client with the given token
@@ -29,6 +42,42 @@ func NewHostingerClient(token, version string) *HostingerClient {
 	}
 }
 
+// claimInitialVirtualMachine serializes adopt_initial across resources
+// applied in parallel. It runs find while no other resource is looking,
+// skipping the VPSs already claimed, and claims the VPS it returns.
+func (c *HostingerClient) claimInitialVirtualMachine(find func(claimed map[int]bool) (int, error)) (int, error) {
+	c.claimedVMsMu.Lock()
+	defer c.claimedVMsMu.Unlock()
+
+	if c.claimedVMs == nil {
+		c.claimedVMs = map[int]bool{}
+	}
+	vmID, err := find(c.claimedVMs)
+	if err != nil {
+		return 0, err
+	}
+	c.claimedVMs[vmID] = true
+	return vmID, nil
+}
+
+// releaseInitialVirtualMachine gives up a claim whose setup failed, so that
+// another resource may adopt the VPS.
+func (c *HostingerClient) releaseInitialVirtualMachine(vmID int) {
+	c.claimedVMsMu.Lock()
+	defer c.claimedVMsMu.Unlock()
+	delete(c.claimedVMs, vmID)
+}
+
+// lockDNSZone serializes changes to the DNS zone of a domain and returns the
+// function that releases it. The API only offers whole-entry writes, so
+// resources reading a zone, changing it and writing it back would otherwise
+// lose each other's changes when Terraform applies them in parallel.
+func (c *HostingerClient) lockDNSZone(domain string) func() {
+	mu, _ := c.dnsZoneLocks.LoadOrStore(normalizeDNSName(domain), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
 type PaymentMethod struct {
 	ID        int  `json:"id"`
 	IsDefault bool `json:"is_default"`
@@ -116,11 +165,12 @@ func (c *HostingerClient) GetSubscriptionDetails(subscriptionID string) (*Subscr
 
 // SubscriptionDetails contains detailed subscription information including the plan
 type SubscriptionDetails struct {
-	ID       string `json:"id"`
-	Status   string `json:"status"`
-	Plan     string `json:"plan"`
-	ItemID   string `json:"item_id"`
-	Product  struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Plan      string `json:"plan"`
+	ItemID    string `json:"item_id"`
+	ExpiresAt string `json:"expires_at"`
+	Product   struct {
 		Type       string `json:"type"`
 		ResourceID int    `json:"resource_id"`
 	} `json:"product"`
@@ -158,6 +208,32 @@ func (c *HostingerClient) CancelSubscription(subscriptionID string) error {
 	return nil
 }
 
+// DisableAutoRenewal turns off automatic renewal so the subscription lapses
+// at the end of its current billing period.
+func (c *HostingerClient) DisableAutoRenewal(subscriptionID string) error {
+	url := fmt.Sprintf("%s/api/billing/v1/subscriptions/%s/auto-renewal/disable", c.BaseURL, subscriptionID)
+
+	req, err := http.NewRequest("PATCH", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create disable auto-renewal request: %w", err)
+	}
+
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to disable auto-renewal for subscription %s (HTTP %d): %s", subscriptionID, resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
 // PurchaseVPSSetup defines the setup configuration for purchasing a new VPS.
 type PurchaseVPSSetup struct {
 	DataCenterID        int     `json:"data_center_id"`
@@ -232,8 +308,16 @@ type VirtualMachine struct {
 	TemplateID     int         `json:"template_id,omitempty"`
 	Template       interface{} `json:"template,omitempty"` // Can be string or object
 	DataCenter     interface{} `json:"data_center,omitempty"` // Can be string or object  
+	FirewallID     *int        `json:"firewall_group_id,omitempty"`
+	NS1            string      `json:"ns1,omitempty"`
+	NS2            string      `json:"ns2,omitempty"`
 	OS             string      `json:"os,omitempty"`
 	OSName         string      `json:"os_name,omitempty"`
+	CPUs           int         `json:"cpus,omitempty"`
+	Memory         int         `json:"memory,omitempty"`    // MB
+	Disk           int         `json:"disk,omitempty"`      // MB
+	Bandwidth      int         `json:"bandwidth,omitempty"` // MB
+	CreatedAt      string      `json:"created_at,omitempty"`
 	Resources      struct {
 		CPU    int `json:"cpu"`
 		RAM    int `json:"ram"`
@@ -241,7 +325,85 @@ type VirtualMachine struct {
 	} `json:"resources,omitempty"`
 }
 type IPAddress struct {
+	ID      int    `json:"id,omitempty"`
 	Address string `json:"address"`
+	PTR     string `json:"ptr,omitempty"`
+}
+
+// FindIPAddress returns the IPv4 or IPv6 address of the VPS matching address.
+func (vm *VirtualMachine) FindIPAddress(address string) (*IPAddress, bool) {
+	for _, list := range [][]IPAddress{vm.IPv4, vm.IPv6} {
+		for i := range list {
+			if list[i].Address == address {
+				return &list[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// CPUCount returns the number of vCPUs of the VPS.
+func (vm *VirtualMachine) CPUCount() int {
+	if vm.CPUs > 0 {
+		return vm.CPUs
+	}
+	return vm.Resources.CPU
+}
+
+// MemoryMB returns the memory of the VPS in megabytes.
+func (vm *VirtualMachine) MemoryMB() int {
+	if vm.Memory > 0 {
+		return vm.Memory
+	}
+	return vm.Resources.RAM
+}
+
+// DiskGB returns the disk size of the VPS in gigabytes.
+func (vm *VirtualMachine) DiskGB() int {
+	if vm.Disk > 0 {
+		return vm.Disk / 1024
+	}
+	return vm.Resources.Disk / 1024
+}
+
+// OperatingSystem returns the name of the OS installed on the VPS.
+func (vm *VirtualMachine) OperatingSystem() string {
+	if vm.OSName != "" {
+		return vm.OSName
+	}
+	if vm.OS != "" {
+		return vm.OS
+	}
+	return vm.TemplateName()
+}
+
+// resolveIDs fills TemplateID and DataCenterID from the template and data
+// center objects when the API returned them nested.
+func (vm *VirtualMachine) resolveIDs() {
+	if tmplObj, ok := vm.Template.(map[string]interface{}); ok {
+		if id, ok := tmplObj["id"].(float64); ok {
+			vm.TemplateID = int(id)
+		}
+	}
+	if dcObj, ok := vm.DataCenter.(map[string]interface{}); ok {
+		if id, ok := dcObj["id"].(float64); ok {
+			vm.DataCenterID = int(id)
+		}
+	}
+}
+
+// TemplateName returns the name of the OS template installed on the VPS,
+// whether the API returned the template as a plain string or as an object.
+func (vm *VirtualMachine) TemplateName() string {
+	switch t := vm.Template.(type) {
+	case string:
+		return t
+	case map[string]interface{}:
+		if name, ok := t["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
 }
 
 // GetVirtualMachines lists all VPS instances in the account.
@@ -286,16 +448,16 @@ func (c *HostingerClient) FindVirtualMachineBySubscription(subscriptionID string
 
 // SetupRequest defines the payload to set up (activate) a new VPS.
 type SetupRequest struct {
-	DataCenterID int     `json:"data_center_id"`
-	TemplateID   int     `json:"template_id"`
-	Password     *string `json:"password,omitempty"`
-	Hostname     *string `json:"hostname,omitempty"`
+	DataCenterID        int     `json:"data_center_id"`
+	TemplateID          int     `json:"template_id"`
+	Password            *string `json:"password,omitempty"`
+	Hostname            *string `json:"hostname,omitempty"`
+	PostInstallScriptID *int    `json:"post_install_script_id,omitempty"`
 }
 
 // SetupVirtualMachine activates a newly purchased VPS (with 'initial' state) by installing the OS.
 func (c *HostingerClient) SetupVirtualMachine(vmID int, setup SetupRequest) (*VirtualMachine, error) {
 	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/setup", c.BaseURL, vmID)
-	fmt.Printf("[DEBUG] Setup request body: %+v\n", setup)
 	body := map[string]interface{}{
 		"data_center_id": setup.DataCenterID,
 		"template_id":    setup.TemplateID,
@@ -309,6 +471,10 @@ func (c *HostingerClient) SetupVirtualMachine(vmID int, setup SetupRequest) (*Vi
 		body["password"] = *setup.Password
 	}
 
+	if setup.PostInstallScriptID != nil {
+		body["post_install_script_id"] = *setup.PostInstallScriptID
+	}
+
 	bodyData, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
@@ -375,42 +541,28 @@ func (c *HostingerClient) GetVirtualMachineWithFullDetails(vmID int) (*VirtualMa
 		return nil, err
 	}
 	
-	// Extract IDs from template/datacenter if they're objects
-	if vm.Template != nil {
-		if tmplObj, ok := vm.Template.(map[string]interface{}); ok {
-			if id, exists := tmplObj["id"]; exists {
-				if idFloat, ok := id.(float64); ok {
-					vm.TemplateID = int(idFloat)
-				}
-			}
-		}
-	}
-	
-	if vm.DataCenter != nil {
-		if dcObj, ok := vm.DataCenter.(map[string]interface{}); ok {
-			if id, exists := dcObj["id"]; exists {
-				if idFloat, ok := id.(float64); ok {
-					vm.DataCenterID = int(idFloat)
-				}
-			}
-		}
-	}
+	vm.resolveIDs()
+
+	c.resolvePlan(vm)
 	
-	// Try to get subscription details to enrich with plan information
-	if vm.SubscriptionID != "" {
-		subDetails, err := c.GetSubscriptionDetails(vm.SubscriptionID)
-		if err == nil && subDetails != nil {
-			// Enrich VM with plan information from subscription
-			if subDetails.ItemID != "" {
-				vm.Plan = subDetails.ItemID
-			} else if subDetails.Plan != "" {
-				vm.Plan = subDetails.Plan
-			}
+	return vm, nil
+}
+
+// resolvePlan replaces the plan display name of the VPS (e.g. "KVM 2") with
+// the item ID of its subscription, the form hostinger_vps takes. We don't
+// fail if subscription details can't be fetched, we just use what we have.
+func (c *HostingerClient) resolvePlan(vm *VirtualMachine) {
+	if vm.SubscriptionID == "" {
+		return
+	}
+	subDetails, err := c.GetSubscriptionDetails(vm.SubscriptionID)
+	if err == nil && subDetails != nil {
+		if subDetails.ItemID != "" {
+			vm.Plan = subDetails.ItemID
+		} else if subDetails.Plan != "" {
+			vm.Plan = subDetails.Plan
 		}
-		// We don't fail if subscription details can't be fetched, we just use what we have
 	}
-	
-	return vm, nil
 }
 
 func (c *HostingerClient) UpdateHostname(vmID int, hostname string) error {
@@ -445,7 +597,225 @@ func (c *HostingerClient) UpdateHostname(vmID int, hostname string) error {
 	return nil
 }
 
-func (c *HostingerClient) RecreateVirtualMachine(vmID int, templateID int, password *string, postScriptID *int) error {
+// SetRootPassword changes the root password of a running VPS without reinstalling it.
+func (c *HostingerClient) SetRootPassword(vmID int, password string) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/root-password", c.BaseURL, vmID)
+
+	body := map[string]string{
+		"password": password,
+	}
+	bodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		return err
+	}
+
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set root password failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// SetPanelPassword changes the password of the control panel (CyberPanel,
+// CloudPanel, ...) installed by the VPS template.
+func (c *HostingerClient) SetPanelPassword(vmID int, password string) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/panel-password", c.BaseURL, vmID)
+
+	body := map[string]string{
+		"password": password,
+	}
+	bodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		return err
+	}
+
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set panel password failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// StartRecoveryMode boots the VPS into the recovery environment, using the given
+// root password for the recovery system.
+func (c *HostingerClient) StartRecoveryMode(vmID int, rootPassword string) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/recovery", c.BaseURL, vmID)
+
+	body := map[string]string{
+		"root_password": rootPassword,
+	}
+	bodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("start recovery mode failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// StopRecoveryMode leaves the recovery environment and boots the VPS from its own disk.
+func (c *HostingerClient) StopRecoveryMode(vmID int) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/recovery", c.BaseURL, vmID)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stop recovery mode failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// SetNameservers sets the resolvers used by the VPS. ns2 is optional and
+// omitted when empty.
+func (c *HostingerClient) SetNameservers(vmID int, ns1, ns2 string) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/nameservers", c.BaseURL, vmID)
+
+	body := map[string]string{
+		"ns1": ns1,
+	}
+	if ns2 != "" {
+		body["ns2"] = ns2
+	}
+	bodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("set nameservers failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// CreatePTRRecord sets the reverse DNS entry of one of the VPS IP addresses.
+func (c *HostingerClient) CreatePTRRecord(vmID, ipAddressID int, domain string) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/ptr/%d", c.BaseURL, vmID, ipAddressID)
+
+	body := map[string]string{
+		"domain": domain,
+	}
+	bodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("create PTR record failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// DeletePTRRecord removes the reverse DNS entry of one of the VPS IP addresses.
+func (c *HostingerClient) DeletePTRRecord(vmID, ipAddressID int) error {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/ptr/%d", c.BaseURL, vmID, ipAddressID)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete PTR record failed (HTTP %d): %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
+// RecreateVirtualMachine reinstalls the OS of a VPS with the given template,
+// wiping its disk, and returns the started action.
+func (c *HostingerClient) RecreateVirtualMachine(vmID int, templateID int, password *string, postScriptID *int) (*Action, error) {
 	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/recreate", c.BaseURL, vmID)
 
 	body := map[string]interface{}{
@@ -458,29 +828,89 @@ func (c *HostingerClient) RecreateVirtualMachine(vmID int, templateID int, passw
 		body["post_install_script_id"] = *postScriptID
 	}
 
-	bodyData, err := json.Marshal(body)
+	action, err := c.doVPSAction("POST", url, body)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("recreate VPS failed: %w", err)
 	}
+	return action, nil
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyData))
+// Action is an asynchronous operation started on a VPS, such as a snapshot or a restore.
+type Action struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// GetAction retrieves the current state of an action started on a VPS.
+func (c *HostingerClient) GetAction(vmID, actionID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/actions/%d", c.BaseURL, vmID, actionID)
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	c.addStandardHeaders(req)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		msg, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("recreate VPS failed (HTTP %d): %s", resp.StatusCode, msg)
+		return nil, fmt.Errorf("failed to get action %d (HTTP %d): %s", actionID, resp.StatusCode, string(msg))
 	}
 
-	return nil
+	var action Action
+	if err := json.NewDecoder(resp.Body).Decode(&action); err != nil {
+		return nil, fmt.Errorf("invalid action response: %w", err)
+	}
+	return &action, nil
+}
+
+// doVPSAction sends a request that starts an action on a VPS and decodes the
+// returned action.
+func (c *HostingerClient) doVPSAction(method, url string, body interface{}) (*Action, error) {
+	var reader io.Reader
+	if body != nil {
+		bodyData, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(bodyData)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(msg))
+	}
+
+	var action Action
+	if err := json.NewDecoder(resp.Body).Decode(&action); err != nil {
+		return nil, fmt.Errorf("invalid action response: %w", err)
+	}
+	return &action, nil
 }
 
 func (c *HostingerClient) GetSSHKeyIDsForVM(vmID int) ([]int, error) {
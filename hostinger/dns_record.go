@@ -1,28 +1,32 @@
 package hostinger
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // DNSEntry represents a DNS entry from the Hostinger API
 type DNSEntry struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	TTL     int    `json:"ttl"`
-	Records []struct {
-		Content    string `json:"content"`
-		IsDisabled bool   `json:"is_disabled"`
-	} `json:"records"`
+	Name    string             `json:"name"`
+	Type    string             `json:"type"`
+	TTL     int                `json:"ttl"`
+	Records []DNSRecordContent `json:"records"`
+}
+
+// DNSRecordContent is a single value of a DNS entry.
+type DNSRecordContent struct {
+	Content    string `json:"content"`
+	IsDisabled bool   `json:"is_disabled,omitempty"`
 }
 
 // normalizeDNSName normalizes DNS names for comparison by converting to lowercase and removing trailing dots
@@ -42,7 +46,20 @@ func resourceHostingerDNSRecord() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceHostingerDNSRecordCreate,
 		Read:   resourceHostingerDNSRecordRead,
+		Update: resourceHostingerDNSRecordUpdate,
 		Delete: resourceHostingerDNSRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceHostingerDNSRecordImport,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceHostingerDNSRecordV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceHostingerDNSRecordStateUpgradeV0,
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -65,72 +82,146 @@ func resourceHostingerDNSRecord() *schema.Resource {
 				ForceNew: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: dnsRecordFieldNames,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return dnsContentEqual(d.Get("type").(string), old, new)
+				},
 			},
 			"ttl": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Default:  14400,
-				ForceNew: true,
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Priority of an MX or SRV record.",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Weight of an SRV record.",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Port of an SRV record.",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Host of an MX or SRV record, or the property value of a CAA record.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"flags": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Flags of a CAA record.",
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Property tag of a CAA record.",
+				ValidateFunc: validation.StringInSlice([]string{"issue", "issuewild", "iodef"}, true),
 			},
 		},
+
+		CustomizeDiff: resourceHostingerDNSRecordCustomizeDiff,
 	}
 }
 
-func resourceHostingerDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*HostingerClient)
-
-	zone := d.Get("zone").(string)
-	name := d.Get("name").(string)
-	recordType := d.Get("type").(string)
-	value := d.Get("value").(string)
-	ttl := d.Get("ttl").(int)
+// dnsRecordFieldNames are the attributes that build value from its parts.
+var dnsRecordFieldNames = []string{"priority", "weight", "port", "target", "flags", "tag"}
 
-	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", client.BaseURL, zone)
+// resourceHostingerDNSRecordCustomizeDiff checks the structured fields
+// against the record type and plans the value they compose. Numeric fields
+// default to 0, which is valid for all of them.
+func resourceHostingerDNSRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range append([]string{"type"}, dnsRecordFieldNames...) {
+		if !d.NewValueKnown(k) {
+			return d.SetNewComputed("value")
+		}
+	}
 
-	payload := map[string]interface{}{
-		"overwrite": false,
-		"zone": []map[string]interface{}{
-			{
-				"name": name,
-				"type": recordType,
-				"ttl":  ttl,
-				"records": []map[string]interface{}{
-					{
-						"content": value,
-					},
-				},
-			},
-		},
+	recordType := strings.ToUpper(d.Get("type").(string))
+	if _, ok := d.GetOk("target"); !ok {
+		for _, k := range dnsRecordFieldNames {
+			if _, ok := d.GetOk(k); ok {
+				return fmt.Errorf("%s requires target to be set", k)
+			}
+		}
+		// value is computed, so dropping it from the configuration would
+		// otherwise silently keep the old one
+		if raw := d.GetRawConfig(); !raw.IsNull() && raw.GetAttr("value").IsNull() {
+			return fmt.Errorf("one of value or target must be set")
+		}
+		return nil
 	}
 
-	body, _ := json.Marshal(payload)
+	allowed, ok := dnsStructuredFields[recordType]
+	if !ok {
+		return fmt.Errorf("priority, weight, port, target, flags and tag are only supported for MX, SRV and CAA records, not %s", recordType)
+	}
+	for _, k := range dnsRecordFieldNames {
+		if _, ok := d.GetOk(k); ok && !slices.Contains(allowed, k) {
+			return fmt.Errorf("%s is not supported for %s records", k, recordType)
+		}
+	}
+	if _, ok := d.GetOk("tag"); !ok && recordType == "CAA" {
+		return fmt.Errorf("tag is required for CAA records")
+	}
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
+	value, err := composeDNSRecordValue(recordType, dnsRecordFields{
+		Priority: d.Get("priority").(int),
+		Weight:   d.Get("weight").(int),
+		Port:     d.Get("port").(int),
+		Flags:    d.Get("flags").(int),
+		Tag:      strings.ToLower(d.Get("tag").(string)),
+		Target:   d.Get("target").(string),
+	})
 	if err != nil {
 		return err
 	}
 
-	client.addStandardHeaders(req)
-
-	resp, err := client.HTTPClient.Do(req)
-	if err != nil {
-		return err
+	// Keep the stored spelling when only case, spacing or a trailing dot differ
+	if old, _ := d.GetChange("value"); dnsContentEqual(recordType, old.(string), value) {
+		return nil
 	}
-	defer resp.Body.Close()
+	return d.SetNew("value", value)
+}
 
-	respBody, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to create DNS record: %s", respBody)
+func resourceHostingerDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*HostingerClient)
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	recordType := d.Get("type").(string)
+	value := d.Get("value").(string)
+	ttl := d.Get("ttl").(int)
+
+	// Appending leaves the other values of the name and type alone
+	entry := DNSEntry{Name: name, Type: recordType, TTL: ttl, Records: []DNSRecordContent{{Content: value}}}
+	unlock := client.lockDNSZone(zone)
+	err := client.UpdateDNSZone(zone, false, []DNSEntry{entry})
+	unlock()
+	if err != nil {
+		return fmt.Errorf("failed to create DNS record: %w", err)
 	}
 
 	// Use synthetic ID to track record uniquely
-	d.SetId(fmt.Sprintf("%s|%s|%s", name, recordType, value))
+	id := fmt.Sprintf("%s|%s|%s", name, recordType, value)
 
-	// Use retry logic to handle eventual consistency
+	// Use retry logic to handle eventual consistency. Read clears the ID
+	// while the record is missing, so it is set again on every attempt.
 	err = retry.RetryContext(context.Background(), 30*time.Second, func() *retry.RetryError {
+		d.SetId(id)
 		err := resourceHostingerDNSRecordRead(d, meta)
 		if err != nil {
 			return retry.NonRetryableError(err)
@@ -159,12 +250,10 @@ func resourceHostingerDNSRecordRead(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("zone is required but not set in resource configuration")
 	}
 
-	// Parse synthetic ID
-	parts := strings.Split(d.Id(), "|")
-	if len(parts) != 3 {
-		return fmt.Errorf("unexpected ID format: %s", d.Id())
+	name, recordType, value, err := dnsRecordIdentity(d)
+	if err != nil {
+		return err
 	}
-	name, recordType, value := parts[0], parts[1], parts[2]
 
 	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", client.BaseURL, zone)
 
@@ -199,17 +288,7 @@ func resourceHostingerDNSRecordRead(d *schema.ResourceData, meta interface{}) er
 					continue
 				}
 				
-				// Compare content based on record type
-				var contentMatch bool
-				if recordType == "TXT" {
-					// TXT records: case-sensitive content, handle quotes
-					contentMatch = compareTXTContent(rec.Content, value)
-				} else {
-					// Other records: case-insensitive content comparison
-					contentMatch = strings.EqualFold(rec.Content, value)
-				}
-				
-				if contentMatch {
+				if dnsContentEqual(recordType, rec.Content, value) {
 					// Set all fields including zone which was missing
 					if err := d.Set("zone", zone); err != nil {
 						return fmt.Errorf("error setting zone: %w", err)
@@ -226,7 +305,6 @@ func resourceHostingerDNSRecordRead(d *schema.ResourceData, meta interface{}) er
 					if err := d.Set("ttl", entry.TTL); err != nil {
 						return fmt.Errorf("error setting ttl: %w", err)
 					}
-					d.SetId(fmt.Sprintf("%s|%s|%s", name, recordType, value))
 					return nil
 				}
 			}
@@ -238,205 +316,149 @@ func resourceHostingerDNSRecordRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceHostingerDNSRecordDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceHostingerDNSRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*HostingerClient)
 
-	zone := d.Get("zone").(string)
-
-	// Parse synthetic ID  
-	parts := strings.Split(d.Id(), "|")
-	if len(parts) != 3 {
-		return fmt.Errorf("unexpected ID format: %s", d.Id())
-	}
-	name, recordType, valueToDelete := parts[0], parts[1], parts[2]
-
-	// First, fetch all existing records to see if there are other records we need to preserve
-	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", client.BaseURL, zone)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+	// Spelling out an unchanged value in structured fields changes nothing
+	if !d.HasChanges("value", "ttl") {
+		return resourceHostingerDNSRecordRead(d, meta)
 	}
-	client.addStandardHeaders(req)
 
-	resp, err := client.HTTPClient.Do(req)
-	if err != nil {
+	oldValue, newValue := d.GetChange("value")
+	if err := replaceDNSRecordValue(client, d.Get("zone").(string), d.Get("name").(string), d.Get("type").(string), oldValue.(string), newValue.(string), d.Get("ttl").(int)); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to read DNS records: %s", body)
-	}
+	return resourceHostingerDNSRecordRead(d, meta)
+}
 
-	var entries []DNSEntry
+// replaceDNSRecordValue rewrites the whole entry in one overwriting PUT,
+// swapping the old value for the new one and keeping the values other
+// resources manage.
+func replaceDNSRecordValue(client *HostingerClient, zone, name, recordType, oldValue, newValue string, ttl int) error {
+	defer client.lockDNSZone(zone)()
 
-	if err := json.Unmarshal(body, &entries); err != nil {
-		return fmt.Errorf("failed to unmarshal records: %w", err)
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		return fmt.Errorf("failed to read DNS records: %w", err)
 	}
 
-	// Check if there are other records of the same name/type that we need to preserve
-	hasOtherRecords := false
-	for _, entry := range entries {
-		if normalizeDNSName(entry.Name) == normalizeDNSName(name) && strings.EqualFold(entry.Type, recordType) {
-			for _, rec := range entry.Records {
-				if !rec.IsDisabled {
-					// Compare content appropriately based on record type
-					var contentMatch bool
-					if recordType == "TXT" {
-						contentMatch = compareTXTContent(rec.Content, valueToDelete)
-					} else {
-						contentMatch = strings.EqualFold(rec.Content, valueToDelete)
-					}
-					
-					if !contentMatch {
-						hasOtherRecords = true
-						break
-					}
+	var records []DNSRecordContent
+	written := false
+	if i := findDNSZoneEntry(entries, name, recordType); i >= 0 {
+		for _, rec := range entries[i].Records {
+			switch {
+			case dnsContentEqual(recordType, rec.Content, oldValue), dnsContentEqual(recordType, rec.Content, newValue):
+				if !written {
+					records = append(records, DNSRecordContent{Content: newValue})
+					written = true
 				}
+			default:
+				records = append(records, rec)
 			}
-			break
 		}
 	}
+	if !written {
+		records = append(records, DNSRecordContent{Content: newValue})
+	}
 
-	if hasOtherRecords {
-		// The Hostinger API doesn't support deleting individual records.
-		// We need to delete all and recreate the ones we want to keep.
-		
-		// First, collect all records we want to keep
-		var recordsToKeep []map[string]interface{}
-		for _, entry := range entries {
-			if normalizeDNSName(entry.Name) == normalizeDNSName(name) && strings.EqualFold(entry.Type, recordType) {
-				var keepRecords []map[string]interface{}
-				for _, rec := range entry.Records {
-					if !rec.IsDisabled {
-						// Compare content appropriately based on record type
-						var contentMatch bool
-						if recordType == "TXT" {
-							contentMatch = compareTXTContent(rec.Content, valueToDelete)
-						} else {
-							contentMatch = strings.EqualFold(rec.Content, valueToDelete)
-						}
-						
-						if !contentMatch {
-							keepRecords = append(keepRecords, map[string]interface{}{
-								"content": rec.Content,
-							})
-						}
-					}
-				}
-				if len(keepRecords) > 0 {
-					recordsToKeep = append(recordsToKeep, map[string]interface{}{
-						"name": entry.Name,
-						"type": entry.Type,
-						"ttl":  entry.TTL,
-						"records": keepRecords,
-					})
-				}
-				break
-			}
-		}
-		
-		// Delete all records of this name/type
-		payload := map[string]interface{}{
-			"filters": []map[string]interface{}{
-				{
-					"name": name,
-					"type": recordType,
-				},
-			},
-		}
-
-		body, err = json.Marshal(payload)
-		if err != nil {
-			return fmt.Errorf("failed to marshal delete payload: %w", err)
-		}
+	entry := DNSEntry{Name: name, Type: recordType, TTL: ttl, Records: records}
+	if err := client.UpdateDNSZone(zone, true, []DNSEntry{entry}); err != nil {
+		return fmt.Errorf("failed to update DNS record: %w", err)
+	}
+	return nil
+}
 
-		req, err = http.NewRequest("DELETE", url, bytes.NewBuffer(body))
-		if err != nil {
-			return err
-		}
-		client.addStandardHeaders(req)
+// dnsRecordIdentity returns the name, type and value of the record. The ID
+// keeps the value the record was created with, so the current value is taken
+// from state and the ID is only used when state has none, as after an import.
+func dnsRecordIdentity(d *schema.ResourceData) (string, string, string, error) {
+	parts := strings.SplitN(d.Id(), "|", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("unexpected ID format: %s", d.Id())
+	}
+	name, recordType, value := parts[0], parts[1], parts[2]
+	if v, ok := d.GetOk("value"); ok {
+		value = v.(string)
+	}
+	return name, recordType, value, nil
+}
 
-		resp, err = client.HTTPClient.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+// resourceHostingerDNSRecordImport imports a record from a
+// `zone|name|type|value` ID and reads its TTL from the zone.
+func resourceHostingerDNSRecordImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "|", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected zone|name|type|value", d.Id())
+	}
+	zone, name, recordType, value := parts[0], parts[1], parts[2], parts[3]
 
-		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("failed to delete DNS records: %s", respBody)
+	for k, v := range map[string]string{"zone": zone, "name": name, "type": recordType, "value": value} {
+		if err := d.Set(k, v); err != nil {
+			return nil, fmt.Errorf("error setting %s: %w", k, err)
 		}
-		
-		// Recreate the records we want to keep
-		if len(recordsToKeep) > 0 {
-			// Wait for deletion to propagate
-			time.Sleep(2 * time.Second)
-			
-			recreatePayload := map[string]interface{}{
-				"overwrite": false,
-				"zone": recordsToKeep,
-			}
+	}
+	d.SetId(fmt.Sprintf("%s|%s|%s", name, recordType, value))
 
-			body, err = json.Marshal(recreatePayload)
-			if err != nil {
-				return fmt.Errorf("failed to marshal recreate payload: %w", err)
-			}
+	if err := resourceHostingerDNSRecordRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("DNS record %s %s %q not found in zone %s", name, recordType, value, zone)
+	}
+	return []*schema.ResourceData{d}, nil
+}
 
-			req, err = http.NewRequest("PUT", url, bytes.NewBuffer(body))
-			if err != nil {
-				return err
-			}
-			client.addStandardHeaders(req)
+func resourceHostingerDNSRecordDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*HostingerClient)
 
-			resp, err = client.HTTPClient.Do(req)
-			if err != nil {
-				return err
-			}
-			defer resp.Body.Close()
+	zone := d.Get("zone").(string)
 
-			if resp.StatusCode != http.StatusOK {
-				respBody, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("failed to recreate DNS records: %s", respBody)
-			}
-		}
-		
-		d.SetId("")
-		return nil
+	name, recordType, valueToDelete, err := dnsRecordIdentity(d)
+	if err != nil {
+		return err
 	}
 
-	// Safe to delete - no other records of same type
-	payload := map[string]interface{}{
-		"filters": []map[string]interface{}{
-			{
-				"name": name,
-				"type": recordType,
-			},
-		},
-	}
+	// Other resources may manage the remaining values of the same name and
+	// type, so the zone is held until they are written back
+	defer client.lockDNSZone(zone)()
 
-	body, err = json.Marshal(payload)
+	entries, err := client.GetDNSZone(zone)
 	if err != nil {
-		return fmt.Errorf("failed to marshal delete payload: %w", err)
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to read DNS records: %w", err)
 	}
 
-	req, err = http.NewRequest("DELETE", url, bytes.NewBuffer(body))
-	if err != nil {
-		return err
+	i := findDNSZoneEntry(entries, name, recordType)
+	if i < 0 {
+		d.SetId("")
+		return nil
 	}
-	client.addStandardHeaders(req)
 
-	resp, err = client.HTTPClient.Do(req)
-	if err != nil {
-		return err
+	var keep []DNSRecordContent
+	for _, rec := range entries[i].Records {
+		if !dnsContentEqual(recordType, rec.Content, valueToDelete) {
+			keep = append(keep, rec)
+		}
 	}
-	defer resp.Body.Close()
 
-	respBody, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("failed to delete DNS record: %s", respBody)
+	// The API cannot delete a single value. The entry is rewritten with the
+	// remaining values, disabled ones included, in one PUT, so they never stop
+	// resolving, or removed when none remain.
+	if len(keep) > 0 {
+		entry := entries[i]
+		entry.Records = keep
+		if err := client.UpdateDNSZone(zone, true, []DNSEntry{entry}); err != nil {
+			return fmt.Errorf("failed to delete DNS record: %w", err)
+		}
+	} else {
+		filter := DNSFilter{Name: name, Type: recordType}
+		if err := client.DeleteDNSRecords(zone, []DNSFilter{filter}); err != nil {
+			return fmt.Errorf("failed to delete DNS record: %w", err)
+		}
 	}
 
 	d.SetId("")
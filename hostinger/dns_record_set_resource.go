@@ -0,0 +1,257 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceHostingerDNSRecordSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerDNSRecordSetCreate,
+		ReadContext:   resourceHostingerDNSRecordSetRead,
+		UpdateContext: resourceHostingerDNSRecordSetUpdate,
+		DeleteContext: resourceHostingerDNSRecordSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHostingerDNSRecordSetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Domain of the DNS zone.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Record name relative to the zone, `@` for the apex.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Record type.",
+				ValidateFunc: validation.StringInSlice(dnsRecordTypes, false),
+			},
+			"records": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Description: "Every value of the name and type. Values missing here are removed.",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      14400,
+				Description:  "Time to live in seconds, shared by all values.",
+				ValidateFunc: validation.IntAtLeast(60),
+			},
+		},
+	}
+}
+
+func resourceHostingerDNSRecordSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	recordType := d.Get("type").(string)
+
+	if diags := createDNSRecordSet(client, zone, expandDNSRecordSet(d)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(dnsRecordSetID(zone, name, recordType))
+	return resourceHostingerDNSRecordSetRead(ctx, d, m)
+}
+
+// createDNSRecordSet writes the entry unless the zone already has one with
+// its name and type. Overwriting values created elsewhere would silently
+// take them over.
+func createDNSRecordSet(client *HostingerClient, zone string, entry DNSEntry) diag.Diagnostics {
+	defer client.lockDNSZone(zone)()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read DNS zone %s: %w", zone, err))
+	}
+	if i := findDNSZoneEntry(entries, entry.Name, entry.Type); i >= 0 {
+		return diag.Errorf("DNS zone %s already has %s %s records, import them with: terraform import <address> %s", zone, entry.Name, entry.Type, dnsRecordSetID(zone, entry.Name, entry.Type))
+	}
+
+	if err := client.UpdateDNSZone(zone, true, []DNSEntry{entry}); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create %s %s records in DNS zone %s: %w", entry.Name, entry.Type, zone, err))
+	}
+	return nil
+}
+
+func resourceHostingerDNSRecordSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone, name, recordType, err := parseDNSRecordSetID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read DNS zone %s: %w", zone, err))
+	}
+
+	i := findDNSZoneEntry(entries, name, recordType)
+	if i < 0 {
+		d.SetId("")
+		return nil
+	}
+	entry := entries[i]
+
+	// Keep the spelling of state where the API only differs in case or quoting
+	known := d.Get("records").(*schema.Set).List()
+	var values []interface{}
+	for _, rec := range entry.Records {
+		if rec.IsDisabled {
+			continue
+		}
+		value := rec.Content
+		for _, k := range known {
+			if dnsContentEqual(recordType, value, k.(string)) {
+				value = k.(string)
+				break
+			}
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("zone", zone); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set zone: %w", err))
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set name: %w", err))
+	}
+	if err := d.Set("type", recordType); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set type: %w", err))
+	}
+	if err := d.Set("records", values); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set records: %w", err))
+	}
+	if err := d.Set("ttl", entry.TTL); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set ttl: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerDNSRecordSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone := d.Get("zone").(string)
+
+	if err := updateDNSRecordSet(client, zone, expandDNSRecordSet(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to update %s %s records in DNS zone %s: %w", d.Get("name"), d.Get("type"), zone, err))
+	}
+	return resourceHostingerDNSRecordSetRead(ctx, d, m)
+}
+
+// updateDNSRecordSet overwrites the values of the entry, keeping the values
+// disabled in hPanel. A single overwriting PUT swaps every value at once,
+// there is no moment without records.
+func updateDNSRecordSet(client *HostingerClient, zone string, entry DNSEntry) error {
+	defer client.lockDNSZone(zone)()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		return err
+	}
+	disabled := disabledDNSValues(entries)[dnsZoneKey(entry.Name, entry.Type)]
+	return client.UpdateDNSZone(zone, true, []DNSEntry{withDisabledDNSValues(entry, disabled)})
+}
+
+func resourceHostingerDNSRecordSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	recordType := d.Get("type").(string)
+
+	if err := deleteDNSRecordSet(client, zone, name, recordType); err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete %s %s records from DNS zone %s: %w", name, recordType, zone, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// deleteDNSRecordSet removes the entry, or rewrites it with its disabled
+// values alone when it has some.
+func deleteDNSRecordSet(client *HostingerClient, zone, name, recordType string) error {
+	defer client.lockDNSZone(zone)()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		return err
+	}
+	if disabled, ok := disabledDNSValues(entries)[dnsZoneKey(name, recordType)]; ok {
+		return client.UpdateDNSZone(zone, true, []DNSEntry{disabled})
+	}
+	return client.DeleteDNSRecords(zone, []DNSFilter{{Name: name, Type: recordType}})
+}
+
+func resourceHostingerDNSRecordSetImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	zone, name, recordType, err := parseDNSRecordSetID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(dnsRecordSetID(zone, name, recordType))
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandDNSRecordSet(d *schema.ResourceData) DNSEntry {
+	entry := DNSEntry{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+		TTL:  d.Get("ttl").(int),
+	}
+	for _, v := range d.Get("records").(*schema.Set).List() {
+		entry.Records = append(entry.Records, DNSRecordContent{Content: v.(string)})
+	}
+	return entry
+}
+
+// findDNSZoneEntry returns the index of the entry with the name and type, or -1.
+func findDNSZoneEntry(entries []DNSEntry, name, recordType string) int {
+	key := dnsZoneKey(name, recordType)
+	for i, entry := range entries {
+		if dnsZoneKey(entry.Name, entry.Type) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func dnsRecordSetID(zone, name, recordType string) string {
+	return fmt.Sprintf("%s|%s|%s", zone, name, strings.ToUpper(recordType))
+}
+
+func parseDNSRecordSetID(id string) (string, string, string, error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid hostinger_dns_record_set ID %q, expected zone|name|type", id)
+	}
+	return parts[0], parts[1], strings.ToUpper(parts[2]), nil
+}
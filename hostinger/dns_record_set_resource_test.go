@@ -0,0 +1,192 @@
+package hostinger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseDNSRecordSetID(t *testing.T) {
+	zone, name, recordType, err := parseDNSRecordSetID("example.com|www|a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if zone != "example.com" || name != "www" || recordType != "A" {
+		t.Errorf("unexpected result: %q %q %q", zone, name, recordType)
+	}
+
+	for _, id := range []string{"example.com|www", "|www|A", "example.com|www|A|1.2.3.4"} {
+		if _, _, _, err := parseDNSRecordSetID(id); err == nil {
+			t.Errorf("expected error for %q", id)
+		}
+	}
+}
+
+func TestResourceHostingerDNSRecordSet_Lifecycle(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": defaultDNSEntries(),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSRecordSet()
+	apply := func(state *terraform.InstanceState, records []interface{}, ttl int) *terraform.InstanceState {
+		t.Helper()
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone":    "example.com",
+			"name":    "www",
+			"type":    "A",
+			"records": records,
+			"ttl":     ttl,
+		})
+		diff, err := resource.Diff(context.Background(), state, cfg, client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		newState, diags := resource.Apply(context.Background(), state, diff, client)
+		if diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		return newState
+	}
+
+	state := apply(nil, []interface{}{"203.0.113.10", "203.0.113.11", "203.0.113.12"}, 300)
+	if state.ID != "example.com|www|A" || state.Attributes["records.#"] != "3" {
+		t.Fatalf("unexpected state after create: %v", state)
+	}
+	if len(api.puts) != 1 || !api.puts[0].Overwrite || len(api.puts[0].Zone) != 1 || len(api.puts[0].Zone[0].Records) != 3 {
+		t.Fatalf("expected a single overwriting PUT with 3 values, got %+v", api.puts)
+	}
+
+	// Dropping a value is one more PUT, never a DELETE
+	state = apply(state, []interface{}{"203.0.113.10", "203.0.113.12"}, 600)
+	if len(api.puts) != 2 || len(api.deletes) != 0 {
+		t.Fatalf("expected an update to be a single PUT, got puts %+v deletes %+v", api.puts, api.deletes)
+	}
+	entry := api.zones["example.com"][findDNSZoneEntry(api.zones["example.com"], "www", "A")]
+	if entry.TTL != 600 || len(entry.Records) != 2 {
+		t.Errorf("unexpected zone entry after update: %+v", entry)
+	}
+	if state.Attributes["records.#"] != "2" || state.Attributes["ttl"] != "600" {
+		t.Errorf("unexpected state after update: %v", state.Attributes)
+	}
+
+	d := resource.Data(state)
+	if diags := resourceHostingerDNSRecordSetDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if len(api.deletes) != 1 || api.deletes[0][0] != (DNSFilter{Name: "www", Type: "A"}) {
+		t.Errorf("expected the www A entry to be deleted, got %+v", api.deletes)
+	}
+	if len(api.zones["example.com"]) != 2 {
+		t.Errorf("expected only the defaults to remain, got %+v", api.zones["example.com"])
+	}
+}
+
+func TestResourceHostingerDNSRecordSet_CreateExisting(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": {dnsEntry("@", "TXT", 3600, "v=spf1 -all")},
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := resourceHostingerDNSRecordSet().TestResourceData()
+	for k, v := range map[string]interface{}{"zone": "example.com", "name": "@", "type": "TXT", "records": []interface{}{"google-site-verification=abc"}} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("failed to set %s: %v", k, err)
+		}
+	}
+
+	diags := resourceHostingerDNSRecordSetCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "terraform import") {
+		t.Fatalf("expected an error pointing to import, got %v", diags)
+	}
+	if len(api.puts) != 0 {
+		t.Errorf("expected the existing records to be left alone, got %+v", api.puts)
+	}
+}
+
+func TestResourceHostingerDNSRecordSet_ReadKeepsSpelling(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": {dnsEntry("@", "TXT", 3600, `"v=spf1 -all"`, "google-site-verification=abc")},
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := resourceHostingerDNSRecordSet().TestResourceData()
+	d.SetId("example.com|@|TXT")
+	if err := d.Set("records", []interface{}{"v=spf1 -all", "google-site-verification=abc"}); err != nil {
+		t.Fatalf("failed to set records: %v", err)
+	}
+	if diags := resourceHostingerDNSRecordSetRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	records := d.Get("records").(*schema.Set)
+	if !records.Contains("v=spf1 -all") || records.Contains(`"v=spf1 -all"`) {
+		t.Errorf("expected the unquoted spelling to be kept, got %v", d.Get("records"))
+	}
+}
+
+func TestResourceHostingerDNSRecordSet_KeepsDisabledValues(t *testing.T) {
+	www := dnsEntry("www", "A", 300, "203.0.113.10")
+	www.Records = append(www.Records, DNSRecordContent{Content: "203.0.113.99", IsDisabled: true})
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(), www),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSRecordSet()
+	state := &terraform.InstanceState{ID: "example.com|www|A", Attributes: map[string]string{"id": "example.com|www|A"}}
+	state, diags := resource.RefreshWithoutUpgrade(context.Background(), state, client)
+	if diags.HasError() {
+		t.Fatalf("refresh failed: %v", diags)
+	}
+	if state.Attributes["records.#"] != "1" {
+		t.Fatalf("expected the disabled value to stay out of state, got %v", state.Attributes)
+	}
+
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone":    "example.com",
+		"name":    "www",
+		"type":    "A",
+		"records": []interface{}{"203.0.113.11"},
+		"ttl":     300,
+	})
+	diff, err := resource.Diff(context.Background(), state, cfg, client)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	state, diags = resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+
+	entry := api.zones["example.com"][findDNSZoneEntry(api.zones["example.com"], "www", "A")]
+	want := []DNSRecordContent{{Content: "203.0.113.11"}, {Content: "203.0.113.99", IsDisabled: true}}
+	if !reflect.DeepEqual(entry.Records, want) {
+		t.Errorf("expected the disabled value to survive the update, got %+v", entry.Records)
+	}
+	if state.Attributes["records.#"] != "1" {
+		t.Errorf("expected the disabled value to stay out of state, got %v", state.Attributes)
+	}
+
+	if diags := resourceHostingerDNSRecordSetDelete(context.Background(), resource.Data(state), client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	entry = api.zones["example.com"][findDNSZoneEntry(api.zones["example.com"], "www", "A")]
+	if want := want[1:]; len(api.deletes) != 0 || !reflect.DeepEqual(entry.Records, want) {
+		t.Errorf("expected delete to keep only the disabled value, got %+v deletes %+v", entry.Records, api.deletes)
+	}
+}
@@ -1,7 +1,16 @@
 package hostinger
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestResourceHostingerDNSRecord_Schema(t *testing.T) {
@@ -45,3 +54,260 @@ func TestResourceHostingerDNSRecord_BasicCreate(t *testing.T) {
 		t.Errorf("expected zone to be 'example.com', got %v", v)
 	}
 }
+
+func TestResourceHostingerDNSRecord_UpdateInPlace(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": {dnsEntry("@", "A", 14400, "203.0.113.10", "203.0.113.11")},
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSRecord()
+	state := &terraform.InstanceState{
+		ID: "@|A|203.0.113.10",
+		Attributes: map[string]string{
+			"id":    "@|A|203.0.113.10",
+			"zone":  "example.com",
+			"name":  "@",
+			"type":  "A",
+			"value": "203.0.113.10",
+			"ttl":   "14400",
+		},
+	}
+	apply := func(value string, ttl int) *terraform.InstanceState {
+		t.Helper()
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone":  "example.com",
+			"name":  "@",
+			"type":  "A",
+			"value": value,
+			"ttl":   ttl,
+		})
+		diff, err := resource.Diff(context.Background(), state, cfg, client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if diff.RequiresNew() {
+			t.Fatalf("expected an in-place update, got %+v", diff.Attributes)
+		}
+		newState, diags := resource.Apply(context.Background(), state, diff, client)
+		if diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		return newState
+	}
+
+	state = apply("203.0.113.10", 300)
+	state = apply("203.0.113.20", 300)
+
+	if len(api.puts) != 2 || len(api.deletes) != 0 {
+		t.Fatalf("expected two PUTs and no DELETE, got puts %+v deletes %+v", api.puts, api.deletes)
+	}
+	for _, put := range api.puts {
+		if !put.Overwrite || len(put.Zone) != 1 {
+			t.Errorf("expected a single overwriting entry, got %+v", put)
+		}
+	}
+
+	entry := api.zones["example.com"][0]
+	if entry.TTL != 300 || len(entry.Records) != 2 || entry.Records[0].Content != "203.0.113.20" || entry.Records[1].Content != "203.0.113.11" {
+		t.Errorf("expected the value to be swapped and the sibling kept, got %+v", entry)
+	}
+	if state.ID != "@|A|203.0.113.10" {
+		t.Errorf("expected the ID to stay stable, got %s", state.ID)
+	}
+	if state.Attributes["value"] != "203.0.113.20" || state.Attributes["ttl"] != "300" {
+		t.Errorf("unexpected state after update: %v", state.Attributes)
+	}
+}
+
+func TestResourceHostingerDNSRecord_Import(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": {
+			dnsEntry("www", "A", 300, "203.0.113.10"),
+			dnsEntry("@", "TXT", 3600, `"v=spf1 include:_spf.mail.hostinger.com ~all"`),
+		},
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	tests := []struct {
+		id       string
+		wantID   string
+		wantTTL  int
+		wantErr  string
+		wantName string
+	}{
+		{id: "example.com|www|A|203.0.113.10", wantID: "www|A|203.0.113.10", wantTTL: 300, wantName: "www"},
+		{id: "example.com|@|TXT|v=spf1 include:_spf.mail.hostinger.com ~all", wantID: "@|TXT|v=spf1 include:_spf.mail.hostinger.com ~all", wantTTL: 3600, wantName: "@"},
+		{id: "example.com|www|A|203.0.113.99", wantErr: "not found"},
+		{id: "www|A|203.0.113.10", wantErr: "expected zone|name|type|value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			d := resourceHostingerDNSRecord().TestResourceData()
+			d.SetId(tt.id)
+
+			result, err := resourceHostingerDNSRecordImport(d, client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+			imported := result[0]
+			if imported.Id() != tt.wantID {
+				t.Errorf("expected ID %q, got %q", tt.wantID, imported.Id())
+			}
+			if imported.Get("zone") != "example.com" || imported.Get("name") != tt.wantName {
+				t.Errorf("unexpected zone or name: %v %v", imported.Get("zone"), imported.Get("name"))
+			}
+			if imported.Get("ttl") != tt.wantTTL {
+				t.Errorf("expected ttl %d, got %v", tt.wantTTL, imported.Get("ttl"))
+			}
+		})
+	}
+}
+
+func TestResourceHostingerDNSRecord_ConcurrentChanges(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(), dnsEntry("www", "A", 300, "203.0.113.1", "203.0.113.2", "203.0.113.3", "203.0.113.4")),
+	})
+	api.writeDelay = 20 * time.Millisecond
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSRecord()
+	state := func(value string) *terraform.InstanceState {
+		id := "www|A|" + value
+		return &terraform.InstanceState{ID: id, Attributes: map[string]string{
+			"id": id, "zone": "example.com", "name": "www", "type": "A", "value": value, "ttl": "300",
+		}}
+	}
+
+	// Terraform applies independent resources in parallel: two values are
+	// destroyed, two are changed and one is added, all in the same entry
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for _, value := range []string{"203.0.113.1", "203.0.113.2"} {
+		wg.Add(1)
+		go func(value string) {
+			defer wg.Done()
+			errs <- resourceHostingerDNSRecordDelete(resource.Data(state(value)), client)
+		}(value)
+	}
+	for old, value := range map[string]string{"203.0.113.3": "203.0.113.30", "203.0.113.4": "203.0.113.40"} {
+		wg.Add(1)
+		go func(old, value string) {
+			defer wg.Done()
+			cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"zone": "example.com", "name": "www", "type": "A", "value": value, "ttl": 300,
+			})
+			diff, err := resource.Diff(context.Background(), state(old), cfg, client)
+			if err == nil {
+				_, diags := resource.Apply(context.Background(), state(old), diff, client)
+				if diags.HasError() {
+					err = fmt.Errorf("%v", diags)
+				}
+			}
+			errs <- err
+		}(old, value)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d := resource.TestResourceData()
+		for k, v := range map[string]interface{}{"zone": "example.com", "name": "www", "type": "A", "value": "203.0.113.50", "ttl": 300} {
+			if err := d.Set(k, v); err != nil {
+				errs <- err
+				return
+			}
+		}
+		errs <- resourceHostingerDNSRecordCreate(d, client)
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent change failed: %v", err)
+		}
+	}
+
+	entry := api.zones["example.com"][findDNSZoneEntry(api.zones["example.com"], "www", "A")]
+	got := map[string]bool{}
+	for _, rec := range entry.Records {
+		got[rec.Content] = true
+	}
+	want := []string{"203.0.113.30", "203.0.113.40", "203.0.113.50"}
+	if len(got) != len(want) {
+		t.Errorf("expected values %v, got %+v", want, entry.Records)
+	}
+	for _, value := range want {
+		if !got[value] {
+			t.Errorf("expected %s to survive the concurrent changes, got %+v", value, entry.Records)
+		}
+	}
+	if len(api.deletes) != 0 {
+		t.Errorf("expected values to be removed without deleting the entry, got %+v", api.deletes)
+	}
+}
+
+func TestResourceHostingerDNSRecord_DeleteLastValue(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(), dnsEntry("www", "CNAME", 300, "Target.example.com.")),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := resourceHostingerDNSRecord().TestResourceData()
+	d.SetId("www|CNAME|target.example.com")
+	if err := d.Set("zone", "example.com"); err != nil {
+		t.Fatalf("failed to set zone: %v", err)
+	}
+	if err := resourceHostingerDNSRecordDelete(d, client); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	if len(api.puts) != 0 || len(api.deletes) != 1 || api.deletes[0][0] != (DNSFilter{Name: "www", Type: "CNAME"}) {
+		t.Errorf("expected the entry to be deleted, got puts %+v deletes %+v", api.puts, api.deletes)
+	}
+	if len(api.zones["example.com"]) != 2 {
+		t.Errorf("expected only the defaults to remain, got %+v", api.zones["example.com"])
+	}
+}
+
+func TestResourceHostingerDNSRecord_DeleteKeepsDisabledValues(t *testing.T) {
+	entry := dnsEntry("www", "A", 300, "203.0.113.10")
+	entry.Records = append(entry.Records, DNSRecordContent{Content: "203.0.113.11", IsDisabled: true})
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(), entry),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := resourceHostingerDNSRecord().TestResourceData()
+	d.SetId("www|A|203.0.113.10")
+	if err := d.Set("zone", "example.com"); err != nil {
+		t.Fatalf("failed to set zone: %v", err)
+	}
+	if err := resourceHostingerDNSRecordDelete(d, client); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	if len(api.deletes) != 0 {
+		t.Errorf("expected the entry to be kept, got deletes %+v", api.deletes)
+	}
+	records := api.zones["example.com"][findDNSZoneEntry(api.zones["example.com"], "www", "A")].Records
+	if len(records) != 1 || records[0] != (DNSRecordContent{Content: "203.0.113.11", IsDisabled: true}) {
+		t.Errorf("expected only the disabled value to remain, got %+v", records)
+	}
+}
@@ -0,0 +1,118 @@
+package hostinger
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dnsRecordFields are the parts of an MX, SRV or CAA record value.
+// CAA records keep their property value in Target.
+type dnsRecordFields struct {
+	Priority int
+	Weight   int
+	Port     int
+	Flags    int
+	Tag      string
+	Target   string
+}
+
+// dnsStructuredFields lists, per record type, the structured attributes it
+// accepts.
+var dnsStructuredFields = map[string][]string{
+	"MX":  {"priority", "target"},
+	"SRV": {"priority", "weight", "port", "target"},
+	"CAA": {"flags", "tag", "target"},
+}
+
+var dnsCAAValue = regexp.MustCompile(`^\s*(\d+)\s+(\S+)\s+(.*?)\s*$`)
+
+// composeDNSRecordValue encodes the fields as the value the API stores.
+func composeDNSRecordValue(recordType string, f dnsRecordFields) (string, error) {
+	switch strings.ToUpper(recordType) {
+	case "MX":
+		return fmt.Sprintf("%d %s", f.Priority, f.Target), nil
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", f.Priority, f.Weight, f.Port, f.Target), nil
+	case "CAA":
+		return fmt.Sprintf("%d %s %q", f.Flags, f.Tag, strings.Trim(f.Target, `"`)), nil
+	}
+	return "", fmt.Errorf("structured fields are not supported for %s records", recordType)
+}
+
+// parseDNSRecordValue splits an MX, SRV or CAA value into its fields.
+func parseDNSRecordValue(recordType, value string) (dnsRecordFields, error) {
+	var f dnsRecordFields
+	var err error
+
+	switch strings.ToUpper(recordType) {
+	case "MX":
+		parts := strings.Fields(value)
+		if len(parts) != 2 {
+			return f, fmt.Errorf("invalid MX value %q, expected \"<priority> <target>\"", value)
+		}
+		if f.Priority, err = strconv.Atoi(parts[0]); err != nil {
+			return f, fmt.Errorf("invalid MX priority in %q: %w", value, err)
+		}
+		f.Target = parts[1]
+	case "SRV":
+		parts := strings.Fields(value)
+		if len(parts) != 4 {
+			return f, fmt.Errorf("invalid SRV value %q, expected \"<priority> <weight> <port> <target>\"", value)
+		}
+		for i, dst := range []*int{&f.Priority, &f.Weight, &f.Port} {
+			if *dst, err = strconv.Atoi(parts[i]); err != nil {
+				return f, fmt.Errorf("invalid SRV value %q: %w", value, err)
+			}
+		}
+		f.Target = parts[3]
+	case "CAA":
+		m := dnsCAAValue.FindStringSubmatch(value)
+		if m == nil {
+			return f, fmt.Errorf("invalid CAA value %q, expected \"<flags> <tag> \\\"<value>\\\"\"", value)
+		}
+		if f.Flags, err = strconv.Atoi(m[1]); err != nil {
+			return f, fmt.Errorf("invalid CAA flags in %q: %w", value, err)
+		}
+		f.Tag = m[2]
+		f.Target = strings.Trim(m[3], `"`)
+	default:
+		return f, fmt.Errorf("%s records have no structured value", recordType)
+	}
+	return f, nil
+}
+
+// normalizeDNSRecordValue returns a canonical form of an MX, SRV or CAA value
+// for comparison: single spaces, host names in lower case without the
+// trailing dot, CAA tags in lower case and CAA values quoted.
+func normalizeDNSRecordValue(recordType, value string) (string, bool) {
+	f, err := parseDNSRecordValue(recordType, value)
+	if err != nil {
+		return "", false
+	}
+	if strings.EqualFold(recordType, "CAA") {
+		f.Tag = strings.ToLower(f.Tag)
+	} else {
+		f.Target = normalizeDNSName(f.Target)
+	}
+	normalized, err := composeDNSRecordValue(recordType, f)
+	return normalized, err == nil
+}
+
+// dnsContentEqual compares record values the way the API stores them. TXT
+// values are case sensitive but may come back quoted. MX, SRV and CAA values
+// are compared field by field. Other values ignore case and a trailing dot.
+func dnsContentEqual(recordType, a, b string) bool {
+	switch strings.ToUpper(recordType) {
+	case "TXT":
+		return compareTXTContent(a, b)
+	case "MX", "SRV", "CAA":
+		na, okA := normalizeDNSRecordValue(recordType, a)
+		nb, okB := normalizeDNSRecordValue(recordType, b)
+		if okA && okB {
+			return na == nb
+		}
+	}
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
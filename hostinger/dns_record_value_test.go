@@ -0,0 +1,240 @@
+package hostinger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDNSRecordValue_RoundTrip(t *testing.T) {
+	tests := []struct {
+		recordType string
+		fields     dnsRecordFields
+		value      string
+		equivalent string
+		different  string
+	}{
+		{
+			recordType: "MX",
+			fields:     dnsRecordFields{Priority: 10, Target: "mx1.hostinger.com"},
+			value:      "10 mx1.hostinger.com",
+			equivalent: "10  MX1.Hostinger.com.",
+			different:  "20 mx1.hostinger.com",
+		},
+		{
+			recordType: "SRV",
+			fields:     dnsRecordFields{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"},
+			value:      "10 5 5060 sip.example.com",
+			equivalent: " 10 5 5060   sip.example.com. ",
+			different:  "10 5 5061 sip.example.com",
+		},
+		{
+			recordType: "CAA",
+			fields:     dnsRecordFields{Flags: 0, Tag: "issue", Target: "letsencrypt.org"},
+			value:      `0 issue "letsencrypt.org"`,
+			equivalent: "0 ISSUE letsencrypt.org",
+			different:  `0 issuewild "letsencrypt.org"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recordType, func(t *testing.T) {
+			value, err := composeDNSRecordValue(tt.recordType, tt.fields)
+			if err != nil {
+				t.Fatalf("compose failed: %v", err)
+			}
+			if value != tt.value {
+				t.Errorf("expected %q, got %q", tt.value, value)
+			}
+
+			fields, err := parseDNSRecordValue(tt.recordType, value)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if fields != tt.fields {
+				t.Errorf("expected %+v after the round trip, got %+v", tt.fields, fields)
+			}
+
+			if !dnsContentEqual(tt.recordType, tt.value, tt.equivalent) {
+				t.Errorf("expected %q and %q to be equal", tt.value, tt.equivalent)
+			}
+			if dnsContentEqual(tt.recordType, tt.value, tt.different) {
+				t.Errorf("expected %q and %q to differ", tt.value, tt.different)
+			}
+		})
+	}
+
+	if dnsContentEqual("CAA", `0 issue "letsencrypt.org"`, `0 issue "LetsEncrypt.org"`) {
+		t.Error("expected CAA property values to be compared case sensitively")
+	}
+	if _, err := parseDNSRecordValue("SRV", "10 5 sip.example.com"); err == nil {
+		t.Error("expected an SRV value without a port to be rejected")
+	}
+	if _, err := composeDNSRecordValue("A", dnsRecordFields{Target: "203.0.113.10"}); err == nil {
+		t.Error("expected structured fields to be rejected for A records")
+	}
+}
+
+func TestResourceHostingerDNSRecord_StructuredFieldsDiff(t *testing.T) {
+	record := func(recordType string, fields map[string]interface{}) map[string]interface{} {
+		cfg := map[string]interface{}{"zone": "example.com", "name": "@", "type": recordType}
+		for k, v := range fields {
+			cfg[k] = v
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]interface{}
+		wantValue string
+		wantErr   string
+	}{
+		{
+			name:      "mx",
+			config:    record("MX", map[string]interface{}{"priority": 10, "target": "mx1.hostinger.com"}),
+			wantValue: "10 mx1.hostinger.com",
+		},
+		{
+			name:      "srv",
+			config:    record("SRV", map[string]interface{}{"priority": 0, "weight": 5, "port": 443, "target": "api.example.com"}),
+			wantValue: "0 5 443 api.example.com",
+		},
+		{
+			name:      "caa with default flags",
+			config:    record("CAA", map[string]interface{}{"tag": "Issue", "target": "letsencrypt.org"}),
+			wantValue: `0 issue "letsencrypt.org"`,
+		},
+		{
+			name:    "field of another type",
+			config:  record("MX", map[string]interface{}{"priority": 10, "port": 25, "target": "mx1.hostinger.com"}),
+			wantErr: "port is not supported for MX records",
+		},
+		{
+			name:    "missing tag",
+			config:  record("CAA", map[string]interface{}{"flags": 128, "target": "letsencrypt.org"}),
+			wantErr: "tag is required for CAA records",
+		},
+		{
+			name:    "missing target",
+			config:  record("MX", map[string]interface{}{"priority": 10}),
+			wantErr: "priority requires target to be set",
+		},
+		{
+			name:    "unsupported type",
+			config:  record("A", map[string]interface{}{"target": "203.0.113.10"}),
+			wantErr: "only supported for MX, SRV and CAA records",
+		},
+		{
+			name:    "value and fields",
+			config:  record("MX", map[string]interface{}{"value": "10 mx1.hostinger.com", "priority": 10, "target": "mx1.hostinger.com"}),
+			wantErr: "Conflicting configuration arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := resourceHostingerDNSRecord()
+			cfg := terraform.NewResourceConfigRaw(tt.config)
+
+			if diags := resource.Validate(cfg); diags.HasError() {
+				if tt.wantErr != "" && strings.Contains(diags[0].Summary, tt.wantErr) {
+					return
+				}
+				t.Fatalf("validation failed: %v", diags)
+			}
+			diff, err := resource.Diff(context.Background(), nil, cfg, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("diff failed: %v", err)
+			}
+			if got := diff.Attributes["value"].New; got != tt.wantValue {
+				t.Errorf("expected value %q, got %q", tt.wantValue, got)
+			}
+		})
+	}
+}
+
+func TestResourceHostingerDNSRecord_StructuredFieldsLifecycle(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": {dnsEntry("@", "MX", 14400, "5 mx2.hostinger.com.")},
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	// The record was imported with the value as the API returns it
+	resource := resourceHostingerDNSRecord()
+	state := &terraform.InstanceState{
+		ID: "@|MX|5 mx2.hostinger.com.",
+		Attributes: map[string]string{
+			"id":    "@|MX|5 mx2.hostinger.com.",
+			"zone":  "example.com",
+			"name":  "@",
+			"type":  "MX",
+			"value": "5 mx2.hostinger.com.",
+			"ttl":   "14400",
+		},
+	}
+	plan := func(priority int) *terraform.InstanceDiff {
+		t.Helper()
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone":     "example.com",
+			"name":     "@",
+			"type":     "MX",
+			"priority": priority,
+			"target":   "MX2.hostinger.com",
+		})
+		diff, err := resource.Diff(context.Background(), state, cfg, client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		return diff
+	}
+
+	// Describing the same value with fields does not rewrite it
+	diff := plan(5)
+	if _, ok := diff.Attributes["value"]; ok {
+		t.Fatalf("expected the equivalent value to be kept, got %+v", diff.Attributes["value"])
+	}
+	state, diags := resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+	if len(api.puts) != 0 {
+		t.Fatalf("expected no writes, got %+v", api.puts)
+	}
+	if state.Attributes["value"] != "5 mx2.hostinger.com." || state.Attributes["priority"] != "5" {
+		t.Errorf("unexpected state after adopting the fields: %v", state.Attributes)
+	}
+
+	// A new priority is written in place
+	diff = plan(10)
+	if diff.RequiresNew() || diff.Attributes["value"].New != "10 MX2.hostinger.com" {
+		t.Fatalf("expected an in-place value change, got %+v", diff.Attributes)
+	}
+	state, diags = resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+	entry := api.zones["example.com"][0]
+	if len(api.puts) != 1 || len(entry.Records) != 1 || entry.Records[0].Content != "10 MX2.hostinger.com" {
+		t.Errorf("expected the value to be replaced, got %+v", entry)
+	}
+	if state.Attributes["value"] != "10 MX2.hostinger.com" {
+		t.Errorf("unexpected state after update: %v", state.Attributes)
+	}
+
+	if diff := plan(10); diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes after apply, got %+v", diff.Attributes)
+	}
+}
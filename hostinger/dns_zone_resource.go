@@ -0,0 +1,535 @@
+package hostinger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dnsRecordTypes are the record types accepted by the DNS zone API.
+var dnsRecordTypes = []string{"A", "AAAA", "CNAME", "ALIAS", "MX", "TXT", "NS", "SOA", "SRV", "CAA"}
+
+// DNSFilter selects the entries of a zone to delete by name and type.
+type DNSFilter struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// dnsZoneRecord is a single value of a zone, as declared in a record block.
+type dnsZoneRecord struct {
+	Name  string
+	Type  string
+	Value string
+	TTL   int
+}
+
+func resourceHostingerDNSZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerDNSZoneCreate,
+		ReadContext:   resourceHostingerDNSZoneRead,
+		UpdateContext: resourceHostingerDNSZoneUpdate,
+		DeleteContext: resourceHostingerDNSZoneDelete,
+		CustomizeDiff: resourceHostingerDNSZoneCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHostingerDNSZoneImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Domain whose DNS zone is managed.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"ignore_default_records": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When true, the NS and SOA records at the zone apex, which hPanel manages, are neither read nor removed.",
+			},
+			"record": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Every record of the zone. Records missing here are removed from the zone.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Record name relative to the zone, `@` for the apex.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Record type.",
+							ValidateFunc: validation.StringInSlice(dnsRecordTypes, false),
+						},
+						"value": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Record value.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"ttl": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      14400,
+							Description:  "Time to live in seconds. Records sharing a name and type must share a TTL.",
+							ValidateFunc: validation.IntAtLeast(60),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceHostingerDNSZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := d.Get("zone").(string)
+	if diags := applyDNSZone(m.(*HostingerClient), d); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(zone)
+	return resourceHostingerDNSZoneRead(ctx, d, m)
+}
+
+func resourceHostingerDNSZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone := d.Id()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read DNS zone %s: %w", zone, err))
+	}
+
+	live := dnsZoneRecordsFromEntries(zone, entries, d.Get("ignore_default_records").(bool))
+
+	// Keep the spelling of state where the API only differs in case, quoting
+	// or a trailing dot, so that those differences do not show up as drift
+	known := expandDNSZoneRecords(d.Get("record").(*schema.Set))
+	for i, rec := range live {
+		for _, k := range known {
+			if dnsZoneRecordsEquivalent(rec, k) {
+				live[i].Name, live[i].Value = k.Name, k.Value
+				break
+			}
+		}
+	}
+
+	if err := d.Set("zone", zone); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set zone: %w", err))
+	}
+	if err := d.Set("record", flattenDNSZoneRecords(live)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set record: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges("record", "ignore_default_records") {
+		if diags := applyDNSZone(m.(*HostingerClient), d); diags.HasError() {
+			return diags
+		}
+	}
+	return resourceHostingerDNSZoneRead(ctx, d, m)
+}
+
+func resourceHostingerDNSZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	zone := d.Id()
+	defer client.lockDNSZone(zone)()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read DNS zone %s: %w", zone, err))
+	}
+	disabled := disabledDNSValues(entries)
+
+	// Only the records in state are removed. The apex NS and SOA records stay
+	// in place even when they are managed, the zone stops resolving without
+	// them. Entries with disabled values are rewritten with those alone.
+	var kept []DNSEntry
+	var filters []DNSFilter
+	managed := groupDNSZoneRecords(expandDNSZoneRecords(d.Get("record").(*schema.Set)))
+	for _, key := range sortedDNSZoneKeys(managed) {
+		entry := managed[key]
+		switch {
+		case isDefaultDNSRecord(zone, entry.Name, entry.Type):
+		case len(disabled[key].Records) > 0:
+			kept = append(kept, disabled[key])
+		default:
+			filters = append(filters, DNSFilter{Name: entry.Name, Type: entry.Type})
+		}
+	}
+	if len(kept) > 0 {
+		if err := client.UpdateDNSZone(zone, true, kept); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to delete records of DNS zone %s: %w", zone, err))
+		}
+	}
+	if len(filters) > 0 {
+		if err := client.DeleteDNSRecords(zone, filters); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to delete records of DNS zone %s: %w", zone, err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHostingerDNSZoneImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("zone", d.Id()); err != nil {
+		return nil, fmt.Errorf("failed to set zone: %w", err)
+	}
+	if err := d.Set("ignore_default_records", true); err != nil {
+		return nil, fmt.Errorf("failed to set ignore_default_records: %w", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceHostingerDNSZoneCustomizeDiff rejects record sets the API cannot
+// store: entries sharing a name and type with different TTLs, and default
+// records that would be ignored on read.
+func resourceHostingerDNSZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("record") || !d.NewValueKnown("zone") {
+		return nil
+	}
+	zone := d.Get("zone").(string)
+	ignoreDefaults := d.Get("ignore_default_records").(bool)
+
+	ttls := map[string]int{}
+	for _, rec := range expandDNSZoneRecords(d.Get("record").(*schema.Set)) {
+		if ignoreDefaults && isDefaultDNSRecord(zone, rec.Name, rec.Type) {
+			return fmt.Errorf("record %s %s is managed by hPanel, set ignore_default_records = false to manage it", rec.Name, rec.Type)
+		}
+		key := dnsZoneKey(rec.Name, rec.Type)
+		if ttl, ok := ttls[key]; ok && ttl != rec.TTL {
+			return fmt.Errorf("records %s %s have different ttl values (%d and %d), records sharing a name and type must share a ttl", rec.Name, rec.Type, ttl, rec.TTL)
+		}
+		ttls[key] = rec.TTL
+	}
+	return nil
+}
+
+// applyDNSZone converges the zone to the configured records: entries that
+// differ are written with an overwriting PUT, entries that are no longer
+// configured are removed with a filtered DELETE. Disabled values are not
+// managed and are written back with their entry, which is only removed once
+// none remain.
+func applyDNSZone(client *HostingerClient, d *schema.ResourceData) diag.Diagnostics {
+	zone := d.Get("zone").(string)
+	ignoreDefaults := d.Get("ignore_default_records").(bool)
+	defer client.lockDNSZone(zone)()
+
+	entries, err := client.GetDNSZone(zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read DNS zone %s: %w", zone, err))
+	}
+
+	current := groupDNSZoneRecords(dnsZoneRecordsFromEntries(zone, entries, ignoreDefaults))
+	desired := groupDNSZoneRecords(expandDNSZoneRecords(d.Get("record").(*schema.Set)))
+	disabled := disabledDNSValues(entries)
+
+	var upserts []DNSEntry
+	for _, key := range sortedDNSZoneKeys(desired) {
+		if cur, ok := current[key]; !ok || !dnsEntriesEqual(cur, desired[key]) {
+			upserts = append(upserts, withDisabledDNSValues(desired[key], disabled[key]))
+		}
+	}
+	var removals []DNSFilter
+	for _, key := range sortedDNSZoneKeys(current) {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if kept, ok := disabled[key]; ok {
+			upserts = append(upserts, kept)
+			continue
+		}
+		removals = append(removals, DNSFilter{Name: current[key].Name, Type: current[key].Type})
+	}
+
+	if len(upserts) > 0 {
+		if err := client.UpdateDNSZone(zone, true, upserts); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update DNS zone %s: %w", zone, err))
+		}
+	}
+	if len(removals) > 0 {
+		if err := client.DeleteDNSRecords(zone, removals); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to remove records from DNS zone %s: %w", zone, err))
+		}
+	}
+	return nil
+}
+
+// dnsZoneRecordsFromEntries flattens the enabled values of the API entries,
+// leaving out the hPanel defaults when ignoreDefaults is set.
+func dnsZoneRecordsFromEntries(zone string, entries []DNSEntry, ignoreDefaults bool) []dnsZoneRecord {
+	var records []dnsZoneRecord
+	for _, entry := range entries {
+		if ignoreDefaults && isDefaultDNSRecord(zone, entry.Name, entry.Type) {
+			continue
+		}
+		for _, rec := range entry.Records {
+			if rec.IsDisabled {
+				continue
+			}
+			records = append(records, dnsZoneRecord{
+				Name:  entry.Name,
+				Type:  strings.ToUpper(entry.Type),
+				Value: rec.Content,
+				TTL:   entry.TTL,
+			})
+		}
+	}
+	return records
+}
+
+// disabledDNSValues returns the disabled values of the API entries, grouped
+// into entries keyed by name and type.
+func disabledDNSValues(entries []DNSEntry) map[string]DNSEntry {
+	disabled := map[string]DNSEntry{}
+	for _, entry := range entries {
+		key := dnsZoneKey(entry.Name, entry.Type)
+		for _, rec := range entry.Records {
+			if !rec.IsDisabled {
+				continue
+			}
+			kept, ok := disabled[key]
+			if !ok {
+				kept = DNSEntry{Name: entry.Name, Type: entry.Type, TTL: entry.TTL}
+			}
+			kept.Records = append(kept.Records, rec)
+			disabled[key] = kept
+		}
+	}
+	return disabled
+}
+
+// withDisabledDNSValues adds the disabled values of an entry to the values
+// about to overwrite it, so that the overwrite does not drop them. A disabled
+// value that is written as an enabled one is left out.
+func withDisabledDNSValues(entry, disabled DNSEntry) DNSEntry {
+	records := append([]DNSRecordContent(nil), entry.Records...)
+	for _, rec := range disabled.Records {
+		written := false
+		for _, r := range entry.Records {
+			if dnsContentEqual(entry.Type, r.Content, rec.Content) {
+				written = true
+				break
+			}
+		}
+		if !written {
+			records = append(records, rec)
+		}
+	}
+	entry.Records = records
+	return entry
+}
+
+// groupDNSZoneRecords groups records into API entries keyed by name and type.
+func groupDNSZoneRecords(records []dnsZoneRecord) map[string]DNSEntry {
+	entries := map[string]DNSEntry{}
+	for _, rec := range records {
+		key := dnsZoneKey(rec.Name, rec.Type)
+		entry, ok := entries[key]
+		if !ok {
+			entry = DNSEntry{Name: rec.Name, Type: rec.Type, TTL: rec.TTL}
+		}
+		entry.Records = append(entry.Records, DNSRecordContent{Content: rec.Value})
+		entries[key] = entry
+	}
+	return entries
+}
+
+// dnsEntriesEqual reports whether two entries of the same name and type hold
+// the same TTL and values, in any order.
+func dnsEntriesEqual(a, b DNSEntry) bool {
+	if a.TTL != b.TTL || len(a.Records) != len(b.Records) {
+		return false
+	}
+	matched := make([]bool, len(b.Records))
+	for _, ra := range a.Records {
+		found := false
+		for j, rb := range b.Records {
+			if !matched[j] && dnsContentEqual(a.Type, ra.Content, rb.Content) {
+				matched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func dnsZoneRecordsEquivalent(a, b dnsZoneRecord) bool {
+	return dnsZoneKey(a.Name, a.Type) == dnsZoneKey(b.Name, b.Type) && dnsContentEqual(a.Type, a.Value, b.Value)
+}
+
+// isDefaultDNSRecord reports whether the record is one hPanel maintains at
+// the zone apex.
+func isDefaultDNSRecord(zone, name, recordType string) bool {
+	n := normalizeDNSName(name)
+	apex := n == "@" || n == "" || n == normalizeDNSName(zone)
+	return apex && (strings.EqualFold(recordType, "NS") || strings.EqualFold(recordType, "SOA"))
+}
+
+func dnsZoneKey(name, recordType string) string {
+	n := normalizeDNSName(name)
+	if n == "" {
+		n = "@"
+	}
+	return n + "|" + strings.ToUpper(recordType)
+}
+
+func sortedDNSZoneKeys(entries map[string]DNSEntry) []string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func expandDNSZoneRecords(set *schema.Set) []dnsZoneRecord {
+	if set == nil {
+		return nil
+	}
+	records := make([]dnsZoneRecord, 0, set.Len())
+	for _, raw := range set.List() {
+		m := raw.(map[string]interface{})
+		records = append(records, dnsZoneRecord{
+			Name:  m["name"].(string),
+			Type:  m["type"].(string),
+			Value: m["value"].(string),
+			TTL:   m["ttl"].(int),
+		})
+	}
+	return records
+}
+
+func flattenDNSZoneRecords(records []dnsZoneRecord) []interface{} {
+	flat := make([]interface{}, len(records))
+	for i, rec := range records {
+		flat[i] = map[string]interface{}{
+			"name":  rec.Name,
+			"type":  rec.Type,
+			"value": rec.Value,
+			"ttl":   rec.TTL,
+		}
+	}
+	return flat
+}
+
+// HostingerClient implementations:
+
+// GetDNSZone returns the entries of the DNS zone of a domain.
+func (c *HostingerClient) GetDNSZone(domain string) ([]DNSEntry, error) {
+	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", c.BaseURL, domain)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get DNS zone failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var entries []DNSEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid DNS zone response: %w", err)
+	}
+	return entries, nil
+}
+
+// UpdateDNSZone writes entries to the DNS zone of a domain. With overwrite,
+// the given entries replace the existing ones of the same name and type;
+// without it, their values are added to them.
+func (c *HostingerClient) UpdateDNSZone(domain string, overwrite bool, entries []DNSEntry) error {
+	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", c.BaseURL, domain)
+	body, err := json.Marshal(map[string]interface{}{
+		"overwrite": overwrite,
+		"zone":      entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update DNS zone failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// DeleteDNSRecords removes every entry of the DNS zone of a domain that
+// matches one of the filters.
+func (c *HostingerClient) DeleteDNSRecords(domain string, filters []DNSFilter) error {
+	url := fmt.Sprintf("%s/api/dns/v1/zones/%s", c.BaseURL, domain)
+	body, err := json.Marshal(map[string]interface{}{
+		"filters": filters,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete DNS records failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeDNSZoneAPI keeps DNS zones in memory and applies the PUT and DELETE
+// semantics of /api/dns/v1/zones/{domain}.
+type fakeDNSZoneAPI struct {
+	t       *testing.T
+	mu      sync.Mutex
+	zones   map[string][]DNSEntry
+	puts    []dnsZonePut
+	deletes [][]DNSFilter
+
+	// writeDelay holds back PUTs and DELETEs, so that concurrent clients
+	// read the zone before each other's writes land
+	writeDelay time.Duration
+}
+
+type dnsZonePut struct {
+	Overwrite bool       `json:"overwrite"`
+	Zone      []DNSEntry `json:"zone"`
+}
+
+func newFakeDNSZoneAPI(t *testing.T, zones map[string][]DNSEntry) *fakeDNSZoneAPI {
+	return &fakeDNSZoneAPI{t: t, zones: zones}
+}
+
+func (f *fakeDNSZoneAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	domain := strings.TrimPrefix(r.URL.Path, "/api/dns/v1/zones/")
+	if r.Method != "GET" {
+		time.Sleep(f.writeDelay)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, ok := f.zones[domain]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		_ = json.NewEncoder(w).Encode(entries)
+
+	case "PUT":
+		var put dnsZonePut
+		if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+			f.t.Errorf("failed to decode PUT: %v", err)
+		}
+		f.puts = append(f.puts, put)
+		for _, in := range put.Zone {
+			i := findDNSZoneEntry(entries, in.Name, in.Type)
+			switch {
+			case i < 0:
+				entries = append(entries, in)
+			case put.Overwrite:
+				entries[i] = in
+			default:
+				entries[i].TTL = in.TTL
+				entries[i].Records = append(entries[i].Records, in.Records...)
+			}
+		}
+		f.zones[domain] = entries
+
+	case "DELETE":
+		var body struct {
+			Filters []DNSFilter `json:"filters"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.t.Errorf("failed to decode DELETE: %v", err)
+		}
+		f.deletes = append(f.deletes, body.Filters)
+		for _, filter := range body.Filters {
+			if i := findDNSZoneEntry(entries, filter.Name, filter.Type); i >= 0 {
+				entries = append(entries[:i], entries[i+1:]...)
+			}
+		}
+		f.zones[domain] = entries
+
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func dnsEntry(name, recordType string, ttl int, values ...string) DNSEntry {
+	entry := DNSEntry{Name: name, Type: recordType, TTL: ttl}
+	for _, v := range values {
+		entry.Records = append(entry.Records, DNSRecordContent{Content: v})
+	}
+	return entry
+}
+
+func defaultDNSEntries() []DNSEntry {
+	return []DNSEntry{
+		dnsEntry("@", "NS", 86400, "ns1.dns-parking.com", "ns2.dns-parking.com"),
+		dnsEntry("@", "SOA", 86400, "ns1.dns-parking.com dns.hostinger.com 2024010101 10000 2400 604800 600"),
+	}
+}
+
+func dnsZoneRecordConfig(name, recordType, value string, ttl int) map[string]interface{} {
+	return map[string]interface{}{"name": name, "type": recordType, "value": value, "ttl": ttl}
+}
+
+func TestResourceHostingerDNSZone_Schema(t *testing.T) {
+	resource := resourceHostingerDNSZone()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+}
+
+func TestResourceHostingerDNSZone_Converge(t *testing.T) {
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(),
+			dnsEntry("www", "A", 300, "203.0.113.10"),
+			dnsEntry("old", "CNAME", 14400, "legacy.example.net"),
+			dnsEntry("@", "TXT", 3600, `"v=spf1 -all"`),
+		),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSZone()
+	state := &terraform.InstanceState{ID: "example.com", Attributes: map[string]string{"id": "example.com", "zone": "example.com", "ignore_default_records": "true"}}
+
+	// Refresh picks up everything but the hPanel defaults
+	state, diags := resource.RefreshWithoutUpgrade(context.Background(), state, client)
+	if diags.HasError() {
+		t.Fatalf("refresh failed: %v", diags)
+	}
+	if state.Attributes["record.#"] != "3" {
+		t.Fatalf("expected 3 records after refresh, got %v", state.Attributes)
+	}
+
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone": "example.com",
+		"record": []interface{}{
+			dnsZoneRecordConfig("www", "A", "203.0.113.10", 300),
+			dnsZoneRecordConfig("www", "A", "203.0.113.11", 300),
+			dnsZoneRecordConfig("api", "A", "203.0.113.20", 600),
+			dnsZoneRecordConfig("@", "TXT", "v=spf1 -all", 3600),
+		},
+	})
+	diff, err := resource.Diff(context.Background(), state, cfg, client)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	state, diags = resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+
+	// The unchanged, differently quoted TXT record is not rewritten
+	if len(api.puts) != 1 || !api.puts[0].Overwrite || len(api.puts[0].Zone) != 2 {
+		t.Fatalf("expected one overwriting PUT of the api and www entries, got %+v", api.puts)
+	}
+	for _, entry := range api.puts[0].Zone {
+		if entry.Name == "www" && len(entry.Records) != 2 {
+			t.Errorf("expected www to be written with both values, got %+v", entry)
+		}
+	}
+	if len(api.deletes) != 1 || len(api.deletes[0]) != 1 || api.deletes[0][0] != (DNSFilter{Name: "old", Type: "CNAME"}) {
+		t.Errorf("expected only the old CNAME to be deleted, got %+v", api.deletes)
+	}
+	if i := findDNSZoneEntry(api.zones["example.com"], "@", "NS"); i < 0 {
+		t.Error("expected the default NS records to be kept")
+	}
+	if state.Attributes["record.#"] != "4" {
+		t.Errorf("expected 4 records in state, got %v", state.Attributes)
+	}
+
+	// A second plan is empty
+	diff, err = resource.Diff(context.Background(), state, cfg, client)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes after apply, got %+v", diff.Attributes)
+	}
+}
+
+func TestResourceHostingerDNSZone_Delete(t *testing.T) {
+	for _, ignoreDefaults := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ignore_default_records=%t", ignoreDefaults), func(t *testing.T) {
+			api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+				"example.com": append(defaultDNSEntries(), dnsEntry("www", "A", 300, "203.0.113.10", "203.0.113.11")),
+			})
+			mockServer := httptest.NewServer(api)
+			defer mockServer.Close()
+			client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+			resource := resourceHostingerDNSZone()
+			d := resource.TestResourceData()
+			d.SetId("example.com")
+			if err := d.Set("ignore_default_records", ignoreDefaults); err != nil {
+				t.Fatalf("failed to set ignore_default_records: %v", err)
+			}
+			if diags := resourceHostingerDNSZoneRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("read failed: %v", diags)
+			}
+			if diags := resourceHostingerDNSZoneDelete(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("delete failed: %v", diags)
+			}
+
+			// The apex NS and SOA records are kept even when managed
+			if len(api.deletes) != 1 || len(api.deletes[0]) != 1 || api.deletes[0][0].Name != "www" {
+				t.Errorf("expected a single filter for www A, got %+v", api.deletes)
+			}
+			if len(api.zones["example.com"]) != 2 {
+				t.Errorf("expected only the defaults to remain, got %+v", api.zones["example.com"])
+			}
+		})
+	}
+}
+
+func TestResourceHostingerDNSZone_KeepsDisabledValues(t *testing.T) {
+	www := dnsEntry("www", "A", 300, "203.0.113.10")
+	www.Records = append(www.Records, DNSRecordContent{Content: "203.0.113.99", IsDisabled: true})
+	old := dnsEntry("old", "A", 300, "203.0.113.30")
+	old.Records = append(old.Records, DNSRecordContent{Content: "203.0.113.31", IsDisabled: true})
+	api := newFakeDNSZoneAPI(t, map[string][]DNSEntry{
+		"example.com": append(defaultDNSEntries(), www, old),
+	})
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerDNSZone()
+	state := &terraform.InstanceState{ID: "example.com", Attributes: map[string]string{"id": "example.com", "zone": "example.com", "ignore_default_records": "true"}}
+	state, diags := resource.RefreshWithoutUpgrade(context.Background(), state, client)
+	if diags.HasError() {
+		t.Fatalf("refresh failed: %v", diags)
+	}
+
+	// www changes its enabled value and old is no longer configured
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone":   "example.com",
+		"record": []interface{}{dnsZoneRecordConfig("www", "A", "203.0.113.11", 300)},
+	})
+	diff, err := resource.Diff(context.Background(), state, cfg, client)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	state, diags = resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+
+	entries := api.zones["example.com"]
+	expected := map[string][]DNSRecordContent{
+		"www": {{Content: "203.0.113.11"}, {Content: "203.0.113.99", IsDisabled: true}},
+		"old": {{Content: "203.0.113.31", IsDisabled: true}},
+	}
+	for name, want := range expected {
+		i := findDNSZoneEntry(entries, name, "A")
+		if i < 0 {
+			t.Errorf("expected the %s entry to be kept", name)
+			continue
+		}
+		if got := entries[i].Records; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %s to hold %+v, got %+v", name, want, got)
+		}
+	}
+	if len(api.deletes) != 0 {
+		t.Errorf("expected no entry to be deleted, got %+v", api.deletes)
+	}
+
+	// Destroy keeps them as well
+	d := resource.Data(state)
+	if diags := resourceHostingerDNSZoneDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	entries = api.zones["example.com"]
+	i := findDNSZoneEntry(entries, "www", "A")
+	if want := []DNSRecordContent{{Content: "203.0.113.99", IsDisabled: true}}; i < 0 || !reflect.DeepEqual(entries[i].Records, want) {
+		t.Errorf("expected www to keep only its disabled value, got %+v", entries)
+	}
+}
+
+func TestResourceHostingerDNSZone_CustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "mismatched ttl",
+			config: map[string]interface{}{
+				"zone": "example.com",
+				"record": []interface{}{
+					dnsZoneRecordConfig("www", "A", "203.0.113.10", 300),
+					dnsZoneRecordConfig("www", "A", "203.0.113.11", 600),
+				},
+			},
+			wantErr: "different ttl",
+		},
+		{
+			name: "ignored default",
+			config: map[string]interface{}{
+				"zone":   "example.com",
+				"record": []interface{}{dnsZoneRecordConfig("@", "NS", "ns1.example.net", 86400)},
+			},
+			wantErr: "managed by hPanel",
+		},
+		{
+			name: "managed default",
+			config: map[string]interface{}{
+				"zone":                   "example.com",
+				"ignore_default_records": false,
+				"record":                 []interface{}{dnsZoneRecordConfig("@", "NS", "ns1.example.net", 86400)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resourceHostingerDNSZone().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), nil)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHostingerClient_LockDNSZone(t *testing.T) {
+	client := &HostingerClient{}
+	unlock := client.lockDNSZone("example.com")
+
+	// Another zone is not held up
+	done := make(chan struct{})
+	go func() {
+		client.lockDNSZone("example.net")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected another zone to be locked independently")
+	}
+
+	// The same zone, spelled differently, waits for the unlock
+	for _, domain := range []string{"Example.com", "example.com."} {
+		acquired := make(chan struct{})
+		go func() {
+			client.lockDNSZone(domain)()
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+			t.Fatalf("expected the zone to stay locked for %s", domain)
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %s to be locked after the unlock", domain)
+		}
+		unlock = client.lockDNSZone("example.com")
+	}
+	unlock()
+}
+
+func TestDNSEntriesEqual(t *testing.T) {
+	a := dnsEntry("@", "TXT", 3600, `"v=spf1 -all"`, "google-site-verification=abc")
+	b := dnsEntry("@", "TXT", 3600, "google-site-verification=abc", "v=spf1 -all")
+	if !dnsEntriesEqual(a, b) {
+		t.Error("expected entries with the same values in another order and quoting to be equal")
+	}
+	if dnsEntriesEqual(a, dnsEntry("@", "TXT", 3600, "google-site-verification=ABC", "v=spf1 -all")) {
+		t.Error("expected TXT values to be compared case sensitively")
+	}
+	if dnsEntriesEqual(a, dnsEntry("@", "TXT", 300, "google-site-verification=abc", "v=spf1 -all")) {
+		t.Error("expected a TTL change to be detected")
+	}
+	if !dnsEntriesEqual(dnsEntry("www", "CNAME", 300, "Target.example.com."), dnsEntry("www", "CNAME", 300, "target.example.com")) {
+		t.Error("expected CNAME values to ignore case and the trailing dot")
+	}
+}
@@ -23,12 +23,29 @@ func Provider() *schema.Provider {
 			"hostinger_vps":                     resourceHostingerVPS(),
 			"hostinger_vps_post_install_script": resourceHostingerVPSPostInstallScript(),
 			"hostinger_vps_ssh_key":             resourceHostingerVPSSSHKey(),
+			"hostinger_vps_panel_password":      resourceHostingerVPSPanelPassword(),
+			"hostinger_vps_recovery_mode":       resourceHostingerVPSRecoveryMode(),
+			"hostinger_vps_ptr_record":          resourceHostingerVPSPTRRecord(),
+			"hostinger_vps_firewall":            resourceHostingerVPSFirewall(),
+			"hostinger_vps_firewall_rule":       resourceHostingerVPSFirewallRule(),
+			"hostinger_vps_firewall_attachment": resourceHostingerVPSFirewallAttachment(),
+			"hostinger_vps_snapshot":            resourceHostingerVPSSnapshot(),
+			"hostinger_vps_backup_restore":      resourceHostingerVPSBackupRestore(),
+			"hostinger_vps_docker_project":      resourceHostingerVPSDockerProject(),
+			"hostinger_vps_group":               resourceHostingerVPSGroup(),
 			"hostinger_dns_record":              resourceHostingerDNSRecord(),
+			"hostinger_dns_zone":                resourceHostingerDNSZone(),
+			"hostinger_dns_record_set":          resourceHostingerDNSRecordSet(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"hostinger_vps_templates":    dataSourceHostingerVPSTemplates(),
 			"hostinger_vps_data_centers": dataSourceHostingerVPSDataCenters(),
 			"hostinger_vps_plans":        dataSourceHostingerVPSPlans(),
+			"hostinger_vps_backups":      dataSourceHostingerVPSBackups(),
+			"hostinger_vps_malware_scan": dataSourceHostingerVPSMalwareScan(),
+			"hostinger_vps_metrics":      dataSourceHostingerVPSMetrics(),
+			"hostinger_vps":              dataSourceHostingerVPS(),
+			"hostinger_vps_list":         dataSourceHostingerVPSList(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -0,0 +1,154 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources declared no SchemaVersion until version 1, so every state written
+// before then is version 0. The V0 schemas below are frozen copies of the
+// attribute types of the last release without a SchemaVersion and must not
+// change; shape changes get a new version and a new upgrader instead.
+
+func resourceHostingerVPSV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"plan":                   {Type: schema.TypeString, Required: true},
+			"data_center_id":         {Type: schema.TypeInt, Required: true},
+			"template_id":            {Type: schema.TypeInt, Required: true},
+			"password":               {Type: schema.TypeString, Optional: true, Sensitive: true},
+			"hostname":               {Type: schema.TypeString, Optional: true, Computed: true},
+			"payment_method_id":      {Type: schema.TypeInt, Optional: true},
+			"post_install_script_id": {Type: schema.TypeInt, Optional: true},
+			"ssh_key_ids":            {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+			"ipv4_address":           {Type: schema.TypeString, Computed: true},
+			"ipv6_address":           {Type: schema.TypeString, Computed: true},
+			"vps_id":                 {Type: schema.TypeInt, Computed: true},
+			"status":                 {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+// resourceHostingerVPSStateUpgradeV0 fills in the arguments added since
+// version 0. States written before they existed would otherwise show a
+// spurious in-place update on the first plan.
+func resourceHostingerVPSStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+
+	if rawState["deletion_protection"] == nil {
+		rawState["deletion_protection"] = false
+	}
+	if v, _ := rawState["on_destroy"].(string); v == "" {
+		rawState["on_destroy"] = vpsOnDestroyCancel
+	}
+	if rawState["adopt_initial"] == nil {
+		rawState["adopt_initial"] = false
+	}
+	if rawState["reinstall_on_script_change"] == nil {
+		rawState["reinstall_on_script_change"] = false
+	}
+
+	// Update and Delete address the VPS through vps_id, keep it in step with
+	// the ID. An ID that is not numeric is left for Read to drop.
+	if id, ok := rawState["id"].(string); ok && numberFromState(rawState["vps_id"]) == 0 {
+		if vmID, err := strconv.Atoi(id); err == nil {
+			rawState["vps_id"] = vmID
+		}
+	}
+	return rawState, nil
+}
+
+func resourceHostingerDNSRecordV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id":    {Type: schema.TypeString, Computed: true},
+			"zone":  {Type: schema.TypeString, Required: true},
+			"name":  {Type: schema.TypeString, Required: true},
+			"type":  {Type: schema.TypeString, Required: true},
+			"value": {Type: schema.TypeString, Required: true},
+			"ttl":   {Type: schema.TypeInt, Optional: true},
+		},
+	}
+}
+
+// resourceHostingerDNSRecordStateUpgradeV0 checks the `name|type|value` ID,
+// restores name, type and value from it when they are missing, and records the
+// default TTL where none was stored.
+func resourceHostingerDNSRecordStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+
+	id, _ := rawState["id"].(string)
+	parts := strings.SplitN(id, "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected hostinger_dns_record ID %q in state, expected name|type|value", id)
+	}
+	for i, key := range []string{"name", "type", "value"} {
+		if v, _ := rawState[key].(string); v == "" {
+			rawState[key] = parts[i]
+		}
+	}
+
+	if numberFromState(rawState["ttl"]) == 0 {
+		rawState["ttl"] = 14400
+	}
+	return rawState, nil
+}
+
+func resourceHostingerVPSSSHKeyV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+			"key":  {Type: schema.TypeString, Required: true, Sensitive: true},
+		},
+	}
+}
+
+func resourceHostingerVPSPostInstallScriptV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":    {Type: schema.TypeString, Required: true},
+			"content": {Type: schema.TypeString, Required: true},
+		},
+	}
+}
+
+// numericIDStateUpgradeV0 carries a version 0 state over unchanged. An ID
+// that is not numeric is passed through as well and left for Read to handle.
+func numericIDStateUpgradeV0(resourceType string) schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		if rawState == nil {
+			return nil, nil
+		}
+		if id, _ := rawState["id"].(string); !isNumericID(id) {
+			log.Printf("[WARN] %s has the non-numeric ID %q in state", resourceType, id)
+		}
+		return rawState, nil
+	}
+}
+
+// isNumericID reports whether id is a positive numeric API ID.
+func isNumericID(id string) bool {
+	n, err := strconv.Atoi(id)
+	return err == nil && n > 0
+}
+
+// numberFromState reads a number from raw JSON state, where it is decoded as
+// float64, or from state built in Go, where it is an int.
+func numberFromState(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// loadStateFixture returns the attributes of every instance of resourceType in
+// the state file testdata/state/<name>, checking they were written at version.
+func loadStateFixture(t *testing.T, name, resourceType string, version int) []map[string]interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "state", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var state struct {
+		Resources []struct {
+			Type      string `json:"type"`
+			Instances []struct {
+				SchemaVersion int                    `json:"schema_version"`
+				Attributes    map[string]interface{} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	var instances []map[string]interface{}
+	for _, r := range state.Resources {
+		if r.Type != resourceType {
+			continue
+		}
+		for _, inst := range r.Instances {
+			if inst.SchemaVersion != version {
+				t.Fatalf("fixture instance has schema_version %d, expected %d", inst.SchemaVersion, version)
+			}
+			instances = append(instances, inst.Attributes)
+		}
+	}
+	if len(instances) == 0 {
+		t.Fatalf("fixture %s has no %s instances", name, resourceType)
+	}
+	return instances
+}
+
+// upgradeState runs every upgrader of the resource from version 0, the way
+// Terraform does when it loads an old state.
+func upgradeState(t *testing.T, r *schema.Resource, rawState map[string]interface{}) (map[string]interface{}, error) {
+	t.Helper()
+
+	if r.SchemaVersion != len(r.StateUpgraders) {
+		t.Fatalf("SchemaVersion %d but %d upgraders", r.SchemaVersion, len(r.StateUpgraders))
+	}
+	var err error
+	for i, u := range r.StateUpgraders {
+		if u.Version != i {
+			t.Fatalf("upgrader %d declares version %d", i, u.Version)
+		}
+		if rawState, err = u.Upgrade(context.Background(), rawState, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Every upgraded attribute must still exist in the current schema
+	for k := range rawState {
+		if _, ok := r.Schema[k]; !ok && k != "id" {
+			t.Errorf("upgraded state has attribute %q unknown to the current schema", k)
+		}
+	}
+	return rawState, nil
+}
+
+func TestResourceHostingerVPSStateUpgrade(t *testing.T) {
+	r := resourceHostingerVPS()
+	instances := loadStateFixture(t, "hostinger_vps_v0.tfstate", "hostinger_vps", 0)
+
+	state, err := upgradeState(t, r, instances[0])
+	if err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"deletion_protection":        false,
+		"on_destroy":                 "cancel",
+		"adopt_initial":              false,
+		"reinstall_on_script_change": false,
+		"vps_id":                     float64(123456),
+		"plan":                       "hostingercom-vps-kvm2-usd-1m",
+		"hostname":                   "web-01.example.com",
+	}
+	for k, want := range expected {
+		if got := state[k]; got != want {
+			t.Errorf("expected %s to be %v, got %v", k, want, got)
+		}
+	}
+	if keys := state["ssh_key_ids"].([]interface{}); len(keys) != 2 {
+		t.Errorf("expected ssh_key_ids to be kept, got %v", keys)
+	}
+}
+
+func TestResourceHostingerVPSStateUpgrade_MissingVPSID(t *testing.T) {
+	state, err := resourceHostingerVPSStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":         "654321",
+		"on_destroy": "forget",
+	}, nil)
+	if err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+	if state["vps_id"] != 654321 || state["on_destroy"] != "forget" {
+		t.Errorf("unexpected upgraded state: %v", state)
+	}
+}
+
+func TestResourceHostingerVPSStateUpgrade_NonNumericID(t *testing.T) {
+	state, err := upgradeState(t, resourceHostingerVPS(), map[string]interface{}{"id": "web-01"})
+	if err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+	if state["id"] != "web-01" || state["vps_id"] != nil {
+		t.Errorf("expected the ID to be passed through untouched, got %v", state)
+	}
+}
+
+func TestResourceHostingerDNSRecordStateUpgrade(t *testing.T) {
+	r := resourceHostingerDNSRecord()
+	instances := loadStateFixture(t, "hostinger_dns_record_v0.tfstate", "hostinger_dns_record", 0)
+
+	tests := []map[string]interface{}{
+		{"name": "www", "type": "A", "value": "203.0.113.10", "ttl": float64(3600), "zone": "example.com"},
+		{"name": "@", "type": "TXT", "value": "v=spf1 include:_spf.mail.hostinger.com ~all", "ttl": 14400, "zone": "example.com"},
+	}
+	for i, expected := range tests {
+		state, err := upgradeState(t, r, instances[i])
+		if err != nil {
+			t.Fatalf("instance %d: upgrade failed: %v", i, err)
+		}
+		for k, want := range expected {
+			if got := state[k]; got != want {
+				t.Errorf("instance %d: expected %s to be %v, got %v", i, k, want, got)
+			}
+		}
+	}
+
+	if _, err := resourceHostingerDNSRecordStateUpgradeV0(context.Background(), map[string]interface{}{"id": "www"}, nil); err == nil {
+		t.Error("expected an error for a malformed ID")
+	}
+
+	// The value may contain the separator, as Read accepts
+	state, err := resourceHostingerDNSRecordStateUpgradeV0(context.Background(), map[string]interface{}{"id": `@|CAA|0 issue "ca.example.net; account=a|b"`}, nil)
+	if err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+	if state["name"] != "@" || state["type"] != "CAA" || state["value"] != `0 issue "ca.example.net; account=a|b"` {
+		t.Errorf("unexpected upgraded state: %v", state)
+	}
+}
+
+func TestNumericIDStateUpgrade(t *testing.T) {
+	fixtures := []struct {
+		file         string
+		resourceType string
+		resource     *schema.Resource
+	}{
+		{"hostinger_vps_ssh_key_v0.tfstate", "hostinger_vps_ssh_key", resourceHostingerVPSSSHKey()},
+		{"hostinger_vps_post_install_script_v0.tfstate", "hostinger_vps_post_install_script", resourceHostingerVPSPostInstallScript()},
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vps/v1/public-keys" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data": [{"id": 7, "name": "laptop", "key": "ssh-ed25519 AAAA"}]}`))
+	}))
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	for _, f := range fixtures {
+		t.Run(f.resourceType, func(t *testing.T) {
+			instances := loadStateFixture(t, f.file, f.resourceType, 0)
+
+			original := make(map[string]interface{}, len(instances[0]))
+			for k, v := range instances[0] {
+				original[k] = v
+			}
+
+			state, err := upgradeState(t, f.resource, instances[0])
+			if err != nil {
+				t.Fatalf("upgrade failed: %v", err)
+			}
+			for k, v := range original {
+				if state[k] != v {
+					t.Errorf("expected %s to be unchanged, got %v", k, state[k])
+				}
+			}
+
+			// A non-numeric ID is passed through and dropped by Read
+			state, err = upgradeState(t, f.resource, map[string]interface{}{"id": "not-a-number"})
+			if err != nil || state["id"] != "not-a-number" {
+				t.Fatalf("expected the ID to be passed through, got %v, %v", state, err)
+			}
+			d := f.resource.TestResourceData()
+			d.SetId("not-a-number")
+			if diags := f.resource.ReadContext(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("read failed: %v", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("expected Read to drop the non-numeric ID, got %q", d.Id())
+			}
+		})
+	}
+}
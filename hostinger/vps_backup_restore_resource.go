@@ -0,0 +1,160 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type Backup struct {
+	ID        int    `json:"id"`
+	Location  string `json:"location"`
+	CreatedAt string `json:"created_at"`
+}
+
+func resourceHostingerVPSBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSBackupRestoreCreate,
+		ReadContext:   resourceHostingerVPSBackupRestoreRead,
+		DeleteContext: resourceHostingerVPSBackupRestoreDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS to restore.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"backup_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the backup to restore, as listed by the `hostinger_vps_backups` data source.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the restore again whenever they change.",
+			},
+			"restored_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the restore completed.",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+	backupID := d.Get("backup_id").(int)
+
+	action, err := client.RestoreBackup(vmID, backupID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to restore backup %d: %w", backupID, err))
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", vmID, backupID))
+
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for backup restore of VPS %d: %w", vmID, err))
+	}
+	if _, err := waitForVPSState(ctx, client, vmID, d.Timeout(schema.TimeoutCreate), vpsStateRunning); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for VPS %d to run after restore: %w", vmID, err))
+	}
+
+	if err := d.Set("restored_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set restored_at: %w", err))
+	}
+	return resourceHostingerVPSBackupRestoreRead(ctx, d, m)
+}
+
+func resourceHostingerVPSBackupRestoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	// The restore is a one-off action; only drop it once the VPS itself is gone
+	if _, err := client.GetVirtualMachine(vmID); err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSBackupRestoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A restore cannot be undone, destroying only removes it from state.
+	d.SetId("")
+	return nil
+}
+
+// HostingerClient implementations:
+
+// GetBackups lists the automatic backups of a VPS, following pagination.
+func (c *HostingerClient) GetBackups(vmID int) ([]Backup, error) {
+	var backups []Backup
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/backups?page=%d", c.BaseURL, vmID, page)
+		req, _ := http.NewRequest("GET", url, nil)
+		c.addStandardHeaders(req)
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, ErrNotFound
+		}
+		if resp.StatusCode != http.StatusOK {
+			msg, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("list backups failed (HTTP %d): %s", resp.StatusCode, msg)
+		}
+
+		var result struct {
+			Data []Backup `json:"data"`
+			Meta struct {
+				CurrentPage int `json:"current_page"`
+				PerPage     int `json:"per_page"`
+				Total       int `json:"total"`
+			} `json:"meta"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, result.Data...)
+		if len(result.Data) == 0 || len(backups) >= result.Meta.Total {
+			return backups, nil
+		}
+	}
+}
+
+func (c *HostingerClient) RestoreBackup(vmID, backupID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/backups/%d/restore", c.BaseURL, vmID, backupID)
+	action, err := c.doVPSAction("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("restore backup failed: %w", err)
+	}
+	return action, nil
+}
@@ -0,0 +1,81 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetBackups_Pagination(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vps/v1/virtual-machines/42/backups" {
+			t.Fatalf("unexpected request path: %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"data": [{"id": 1, "location": "nl-srv-openvzbackups", "created_at": "2026-10-04T00:00:00Z"}], "meta": {"current_page": 1, "per_page": 1, "total": 2}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data": [{"id": 2, "location": "nl-srv-openvzbackups", "created_at": "2026-10-11T00:00:00Z"}], "meta": {"current_page": 2, "per_page": 1, "total": 2}}`))
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	backups, err := client.GetBackups(42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(backups) != 2 || backups[0].ID != 1 || backups[1].ID != 2 {
+		t.Errorf("unexpected backups: %+v", backups)
+	}
+}
+
+func TestResourceHostingerVPSBackupRestore_Create(t *testing.T) {
+	state := "running"
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/backups/7/restore":
+			state = "restoring"
+			_ = json.NewEncoder(w).Encode(Action{ID: 200, Name: "backup_restore", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/200":
+			state = "running"
+			_ = json.NewEncoder(w).Encode(Action{ID: 200, State: "success"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "state": state})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPSBackupRestore()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	_ = d.Set("vps_id", 42)
+	_ = d.Set("backup_id", 7)
+
+	if diags := resourceHostingerVPSBackupRestoreCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if d.Id() != "42/7" || d.Get("restored_at") == "" {
+		t.Errorf("unexpected state after restore: id=%q restored_at=%v", d.Id(), d.Get("restored_at"))
+	}
+}
@@ -0,0 +1,271 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// vpsAttributesSchema returns the computed attributes shared by the
+// hostinger_vps and hostinger_vps_list data sources.
+func vpsAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vps_id":          {Type: schema.TypeInt, Computed: true, Description: "ID of the VPS."},
+		"hostname":        {Type: schema.TypeString, Computed: true, Description: "Hostname of the VPS."},
+		"state":           {Type: schema.TypeString, Computed: true, Description: "Current state of the VPS (e.g., running, stopped)."},
+		"plan":            {Type: schema.TypeString, Computed: true, Description: "Plan of the VPS, as the item ID `hostinger_vps` takes."},
+		"subscription_id": {Type: schema.TypeString, Computed: true, Description: "ID of the billing subscription of the VPS."},
+		"data_center_id":  {Type: schema.TypeInt, Computed: true, Description: "ID of the data center hosting the VPS."},
+		"template_id":     {Type: schema.TypeInt, Computed: true, Description: "ID of the installed OS template."},
+		"template_name":   {Type: schema.TypeString, Computed: true, Description: "Name of the installed OS template."},
+		"firewall_id":     {Type: schema.TypeInt, Computed: true, Description: "ID of the active firewall, or 0 when none is active."},
+		"ipv4_address":    {Type: schema.TypeString, Computed: true, Description: "Primary public IPv4 address."},
+		"ipv6_address":    {Type: schema.TypeString, Computed: true, Description: "Primary public IPv6 address."},
+		"ipv4_addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "All public IPv4 addresses.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"ipv6_addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "All public IPv6 addresses.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"nameservers": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Resolvers configured on the VPS.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"resources": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Capacity of the VPS.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cpu":  {Type: schema.TypeInt, Computed: true, Description: "Number of vCPUs."},
+					"ram":  {Type: schema.TypeInt, Computed: true, Description: "Memory, as reported by the API."},
+					"disk": {Type: schema.TypeInt, Computed: true, Description: "Disk size, as reported by the API."},
+				},
+			},
+		},
+	}
+}
+
+func flattenVirtualMachine(vm *VirtualMachine) map[string]interface{} {
+	ipv4 := make([]string, 0, len(vm.IPv4))
+	for _, ip := range vm.IPv4 {
+		ipv4 = append(ipv4, ip.Address)
+	}
+	ipv6 := make([]string, 0, len(vm.IPv6))
+	for _, ip := range vm.IPv6 {
+		ipv6 = append(ipv6, ip.Address)
+	}
+	primary := func(list []string) string {
+		if len(list) > 0 {
+			return list[0]
+		}
+		return ""
+	}
+	firewallID := 0
+	if vm.FirewallID != nil {
+		firewallID = *vm.FirewallID
+	}
+
+	return map[string]interface{}{
+		"vps_id":          vm.ID,
+		"hostname":        vm.Hostname,
+		"state":           vm.State,
+		"plan":            vm.Plan,
+		"subscription_id": vm.SubscriptionID,
+		"data_center_id":  vm.DataCenterID,
+		"template_id":     vm.TemplateID,
+		"template_name":   vm.TemplateName(),
+		"firewall_id":     firewallID,
+		"ipv4_address":    primary(ipv4),
+		"ipv6_address":    primary(ipv6),
+		"ipv4_addresses":  ipv4,
+		"ipv6_addresses":  ipv6,
+		"nameservers":     flattenVPSNameservers(vm),
+		"resources": []map[string]interface{}{{
+			"cpu":  vm.Resources.CPU,
+			"ram":  vm.Resources.RAM,
+			"disk": vm.Resources.Disk,
+		}},
+	}
+}
+
+func dataSourceHostingerVPS() *schema.Resource {
+	s := vpsAttributesSchema()
+	s["vps_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		Description:  "ID of the VPS to look up.",
+		ExactlyOneOf: []string{"vps_id", "hostname", "ip_address"},
+	}
+	s["hostname"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Hostname of the VPS to look up.",
+	}
+	s["ip_address"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "IPv4 or IPv6 address of the VPS to look up.",
+		ValidateFunc: validation.IsIPAddress,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceHostingerVPSRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceHostingerVPSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	vmID := d.Get("vps_id").(int)
+	if vmID == 0 {
+		vms, err := client.GetVirtualMachines()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		hostname := d.Get("hostname").(string)
+		ip := d.Get("ip_address").(string)
+		var matches []int
+		for i := range vms {
+			if hostname != "" && vms[i].Hostname == hostname {
+				matches = append(matches, vms[i].ID)
+			}
+			if _, ok := vms[i].FindIPAddress(ip); ip != "" && ok {
+				matches = append(matches, vms[i].ID)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no VPS found matching the given criteria")
+		case 1:
+			vmID = matches[0]
+		default:
+			return diag.Errorf("%d VPS instances match the given criteria, use vps_id to select one", len(matches))
+		}
+	}
+
+	vm, err := client.GetVirtualMachineWithFullDetails(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			return diag.Errorf("VPS %d not found", vmID)
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	for k, v := range flattenVirtualMachine(vm) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set %s: %w", k, err))
+		}
+	}
+
+	d.SetId(strconv.Itoa(vm.ID))
+	return nil
+}
+
+func dataSourceHostingerVPSList() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostingerVPSListRead,
+		Schema: map[string]*schema.Schema{
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return VPS instances in this state (e.g., running).",
+			},
+			"plan": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return VPS instances on this plan, given as the item ID `hostinger_vps` takes (e.g., hostingercom-vps-kvm2-usd-1m). Matching is case-insensitive.",
+			},
+			"data_center_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return VPS instances in this data center.",
+			},
+			"template_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return VPS instances running this OS template.",
+			},
+			"hostname_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return VPS instances whose hostname matches this regular expression.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "VPS instances matching the filters.",
+				Elem:        &schema.Resource{Schema: vpsAttributesSchema()},
+			},
+		},
+	}
+}
+
+func dataSourceHostingerVPSListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	vms, err := client.GetVirtualMachines()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var hostnameRegex *regexp.Regexp
+	if v, ok := d.GetOk("hostname_regex"); ok {
+		hostnameRegex = regexp.MustCompile(v.(string))
+	}
+	state := d.Get("state").(string)
+	plan := d.Get("plan").(string)
+	dataCenterID := d.Get("data_center_id").(int)
+	templateID := d.Get("template_id").(int)
+
+	instances := make([]map[string]interface{}, 0, len(vms))
+	for i := range vms {
+		vm := &vms[i]
+		vm.resolveIDs()
+		client.resolvePlan(vm)
+
+		if state != "" && vm.State != state {
+			continue
+		}
+		if plan != "" && !strings.EqualFold(vm.Plan, plan) {
+			continue
+		}
+		if dataCenterID != 0 && vm.DataCenterID != dataCenterID {
+			continue
+		}
+		if templateID != 0 && vm.TemplateID != templateID {
+			continue
+		}
+		if hostnameRegex != nil && !hostnameRegex.MatchString(vm.Hostname) {
+			continue
+		}
+
+		instances = append(instances, flattenVirtualMachine(vm))
+	}
+
+	if err := d.Set("instances", instances); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set instances: %w", err))
+	}
+	d.SetId("vps_list")
+	return nil
+}
@@ -0,0 +1,175 @@
+package hostinger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testVPSListResponse = `[
+	{"id": 1, "hostname": "web-1.example.com", "state": "running", "plan": "KVM 2", "subscription_id": "sub-1",
+	 "template": {"id": 1077, "name": "Ubuntu 24.04"}, "data_center_id": 9,
+	 "ipv4": [{"id": 11, "address": "203.0.113.10"}], "resources": {"cpu": 2, "ram": 8192, "disk": 102400}},
+	{"id": 2, "hostname": "web-2.example.com", "state": "stopped", "plan": "KVM 2", "subscription_id": "sub-2",
+	 "template": {"id": 1077, "name": "Ubuntu 24.04"}, "data_center_id": 9,
+	 "ipv4": [{"id": 12, "address": "203.0.113.11"}]},
+	{"id": 3, "hostname": "db-1.example.com", "state": "running", "plan": "KVM 4", "subscription_id": "sub-3",
+	 "template": {"id": 1002, "name": "Debian 12"}, "data_center_id": 17,
+	 "ipv4": [{"id": 13, "address": "203.0.113.12"}], "ipv6": [{"id": 14, "address": "2001:db8::12"}]}
+]`
+
+func newVPSListMockServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/vps/v1/virtual-machines":
+			_, _ = w.Write([]byte(testVPSListResponse))
+		case "/api/vps/v1/virtual-machines/3":
+			_, _ = w.Write([]byte(`{"id": 3, "hostname": "db-1.example.com", "state": "running", "plan": "KVM 4", "subscription_id": "sub-3",
+				"template": {"id": 1002, "name": "Debian 12"}, "data_center_id": 17, "ns1": "1.1.1.1",
+				"ipv4": [{"id": 13, "address": "203.0.113.12"}], "ipv6": [{"id": 14, "address": "2001:db8::12"}],
+				"resources": {"cpu": 4, "ram": 16384, "disk": 204800}}`))
+		case "/api/billing/v1/subscriptions/sub-1", "/api/billing/v1/subscriptions/sub-2":
+			_, _ = w.Write([]byte(`{"id": "sub-1", "item_id": "hostingercom-vps-kvm2-usd-1m"}`))
+		case "/api/billing/v1/subscriptions/sub-3":
+			_, _ = w.Write([]byte(`{"id": "sub-3", "item_id": "hostingercom-vps-kvm4-usd-1m"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestDataSourceHostingerVPS_ByIPAddress(t *testing.T) {
+	mockServer := newVPSListMockServer(t)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := dataSourceHostingerVPS()
+	if err := resource.InternalValidate(nil, false); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	_ = d.Set("ip_address", "2001:db8::12")
+
+	if diags := dataSourceHostingerVPSRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	if d.Id() != "3" || d.Get("hostname") != "db-1.example.com" || d.Get("subscription_id") != "sub-3" {
+		t.Errorf("unexpected VPS: id=%q hostname=%v subscription_id=%v", d.Id(), d.Get("hostname"), d.Get("subscription_id"))
+	}
+	if d.Get("template_id") != 1002 || d.Get("template_name") != "Debian 12" || d.Get("resources.0.cpu") != 4 {
+		t.Errorf("unexpected attributes: template_id=%v template_name=%v cpu=%v", d.Get("template_id"), d.Get("template_name"), d.Get("resources.0.cpu"))
+	}
+	if d.Get("plan") != "hostingercom-vps-kvm4-usd-1m" {
+		t.Errorf("expected the plan as its item ID, got %v", d.Get("plan"))
+	}
+	if d.Get("ipv4_address") != "203.0.113.12" || d.Get("nameservers.0") != "1.1.1.1" {
+		t.Errorf("unexpected network attributes: ipv4=%v ns=%v", d.Get("ipv4_address"), d.Get("nameservers"))
+	}
+}
+
+func TestDataSourceHostingerVPS_NoMatch(t *testing.T) {
+	mockServer := newVPSListMockServer(t)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := dataSourceHostingerVPS().TestResourceData()
+	_ = d.Set("hostname", "missing.example.com")
+
+	if diags := dataSourceHostingerVPSRead(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("expected an error when no VPS matches")
+	}
+}
+
+func TestDataSourceHostingerVPSList_Filters(t *testing.T) {
+	mockServer := newVPSListMockServer(t)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	tests := []struct {
+		name    string
+		filters map[string]interface{}
+		want    []int
+	}{
+		{"no filters", map[string]interface{}{}, []int{1, 2, 3}},
+		{"state", map[string]interface{}{"state": "running"}, []int{1, 3}},
+		{"plan", map[string]interface{}{"plan": "HOSTINGERCOM-VPS-KVM2-USD-1M"}, []int{1, 2}},
+		{"plan display name", map[string]interface{}{"plan": "KVM 2"}, nil},
+		{"data center", map[string]interface{}{"data_center_id": 17}, []int{3}},
+		{"template", map[string]interface{}{"template_id": 1077}, []int{1, 2}},
+		{"hostname regex", map[string]interface{}{"hostname_regex": "^web-"}, []int{1, 2}},
+		{"combined", map[string]interface{}{"hostname_regex": "^web-", "state": "running"}, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dataSourceHostingerVPSList().TestResourceData()
+			for k, v := range tt.filters {
+				_ = d.Set(k, v)
+			}
+
+			if diags := dataSourceHostingerVPSListRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("read failed: %v", diags)
+			}
+
+			instances := d.Get("instances").([]interface{})
+			if len(instances) != len(tt.want) {
+				t.Fatalf("expected %d instances, got %d", len(tt.want), len(instances))
+			}
+			for i, id := range tt.want {
+				if got := instances[i].(map[string]interface{})["vps_id"]; got != id {
+					t.Errorf("instance %d: expected ID %d, got %v", i, id, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDataSourceHostingerVPSList_PlanMatchesVPS(t *testing.T) {
+	mockServer := newVPSListMockServer(t)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	vps := dataSourceHostingerVPS().TestResourceData()
+	_ = vps.Set("vps_id", 3)
+	if diags := dataSourceHostingerVPSRead(context.Background(), vps, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	// The plan of one data source filters the other
+	list := dataSourceHostingerVPSList().TestResourceData()
+	_ = list.Set("plan", vps.Get("plan"))
+	if diags := dataSourceHostingerVPSListRead(context.Background(), list, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	instances := list.Get("instances").([]interface{})
+	if len(instances) != 1 {
+		t.Fatalf("expected the VPS to be listed under its plan %v, got %d instances", vps.Get("plan"), len(instances))
+	}
+	if got := instances[0].(map[string]interface{})["plan"]; got != vps.Get("plan") {
+		t.Errorf("expected both data sources to report plan %v, got %v", vps.Get("plan"), got)
+	}
+}
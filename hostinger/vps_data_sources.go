@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -186,3 +187,53 @@ func dataSourceHostingerVPSPlansRead(ctx context.Context, d *schema.ResourceData
 	d.SetId("plans")
 	return nil
 }
+
+func dataSourceHostingerVPSBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostingerVPSBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the VPS to list backups for.",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Automatic backups of the VPS.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":         {Type: schema.TypeInt, Computed: true, Description: "ID of the backup."},
+						"location":   {Type: schema.TypeString, Computed: true, Description: "Location the backup is stored in."},
+						"created_at": {Type: schema.TypeString, Computed: true, Description: "Time the backup was taken."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHostingerVPSBackupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	result, err := client.GetBackups(vmID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to list backups for VPS %d: %w", vmID, err))
+	}
+
+	backups := make([]map[string]interface{}, len(result))
+	for i, b := range result {
+		backups[i] = map[string]interface{}{
+			"id":         b.ID,
+			"location":   b.Location,
+			"created_at": b.CreatedAt,
+		}
+	}
+
+	if err := d.Set("backups", backups); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set backups: %w", err))
+	}
+	d.SetId(strconv.Itoa(vmID))
+	return nil
+}
@@ -7,21 +7,36 @@ import (
 	"net/http"
 )
 
-// ValidatePlanID checks if the provided plan exists in /billing/v1/catalog
-func (c *HostingerClient) ValidatePlanID(plan string) (bool, error) {
+// VPSTemplate is an OS template as listed by /vps/v1/templates.
+type VPSTemplate struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DataCenter is a VPS location as listed by /vps/v1/data-centers.
+type DataCenter struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	City     string `json:"city"`
+}
+
+// ListPlanIDs returns the price IDs of the /billing/v1/catalog, which are the
+// valid values of a VPS plan.
+func (c *HostingerClient) ListPlanIDs() ([]string, error) {
 	url := c.BaseURL + "/api/billing/v1/catalog"
 	req, _ := http.NewRequest("GET", url, nil)
 	c.addStandardHeaders(req)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		msg, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("failed to list plans (HTTP %d): %s", resp.StatusCode, msg)
+		return nil, fmt.Errorf("failed to list plans (HTTP %d): %s", resp.StatusCode, msg)
 	}
 
 	var catalog []struct {
@@ -30,75 +45,100 @@ func (c *HostingerClient) ValidatePlanID(plan string) (bool, error) {
 		} `json:"prices"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&catalog); err != nil {
-		return false, err
+		return nil, err
 	}
 
+	var ids []string
 	for _, item := range catalog {
 		for _, price := range item.Prices {
-			if price.ID == plan {
-				return true, nil
-			}
+			ids = append(ids, price.ID)
 		}
 	}
-	return false, nil
+	return ids, nil
 }
 
-// ValidateTemplateID checks if a template ID exists
-func (c *HostingerClient) ValidateTemplateID(id int) (bool, error) {
+// ListTemplates returns the available OS templates.
+func (c *HostingerClient) ListTemplates() ([]VPSTemplate, error) {
 	url := c.BaseURL + "/api/vps/v1/templates"
 	req, _ := http.NewRequest("GET", url, nil)
 	c.addStandardHeaders(req)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		msg, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("failed to list templates (HTTP %d): %s", resp.StatusCode, msg)
+		return nil, fmt.Errorf("failed to list templates (HTTP %d): %s", resp.StatusCode, msg)
 	}
 
-	var templates []struct {
-		ID int `json:"id"`
-	}
+	var templates []VPSTemplate
 	if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
-		return false, err
-	}
-
-	for _, t := range templates {
-		if t.ID == id {
-			return true, nil
-		}
+		return nil, err
 	}
-	return false, nil
+	return templates, nil
 }
 
-// ValidateDataCenterID checks if a data center ID exists
-func (c *HostingerClient) ValidateDataCenterID(id int) (bool, error) {
+// ListDataCenters returns the available data centers.
+func (c *HostingerClient) ListDataCenters() ([]DataCenter, error) {
 	url := c.BaseURL + "/api/vps/v1/data-centers"
 	req, _ := http.NewRequest("GET", url, nil)
 	c.addStandardHeaders(req)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		msg, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("failed to list data centers (HTTP %d): %s", resp.StatusCode, msg)
+		return nil, fmt.Errorf("failed to list data centers (HTTP %d): %s", resp.StatusCode, msg)
 	}
 
-	var datacenters []struct {
-		ID int `json:"id"`
-	}
+	var datacenters []DataCenter
 	if err := json.NewDecoder(resp.Body).Decode(&datacenters); err != nil {
+		return nil, err
+	}
+	return datacenters, nil
+}
+
+// ValidatePlanID checks if the provided plan exists in /billing/v1/catalog
+func (c *HostingerClient) ValidatePlanID(plan string) (bool, error) {
+	ids, err := c.ListPlanIDs()
+	if err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if id == plan {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ValidateTemplateID checks if a template ID exists
+func (c *HostingerClient) ValidateTemplateID(id int) (bool, error) {
+	templates, err := c.ListTemplates()
+	if err != nil {
 		return false, err
 	}
+	for _, t := range templates {
+		if t.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
+// ValidateDataCenterID checks if a data center ID exists
+func (c *HostingerClient) ValidateDataCenterID(id int) (bool, error) {
+	datacenters, err := c.ListDataCenters()
+	if err != nil {
+		return false, err
+	}
 	for _, dc := range datacenters {
 		if dc.ID == id {
 			return true, nil
@@ -106,3 +146,45 @@ func (c *HostingerClient) ValidateDataCenterID(id int) (bool, error) {
 	}
 	return false, nil
 }
+
+// closestMatch returns the candidate nearest to value by edit distance, or ""
+// when none is close enough to be a plausible typo.
+func closestMatch(value string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := levenshtein(value, c)
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	limit := len(value) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDistance < 0 || bestDistance > limit {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
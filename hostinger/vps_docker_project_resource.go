@@ -0,0 +1,351 @@
+package hostinger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type DockerContainer struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+}
+
+var dockerExitStatus = regexp.MustCompile(`^Exited \((-?\d+)\)`)
+
+// ExitCode returns the exit code of an exited container, taken from its
+// status such as "Exited (0) 2 minutes ago".
+func (c DockerContainer) ExitCode() (int, bool) {
+	m := dockerExitStatus.FindStringSubmatch(c.Status)
+	if m == nil {
+		return 0, false
+	}
+	code, err := strconv.Atoi(m[1])
+	return code, err == nil
+}
+
+// DockerProjectRequest is the payload to deploy a Docker Manager project.
+type DockerProjectRequest struct {
+	ProjectName string `json:"project_name"`
+	Content     string `json:"content"`
+	Environment string `json:"environment,omitempty"`
+}
+
+func resourceHostingerVPSDockerProject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSDockerProjectCreate,
+		ReadContext:   resourceHostingerVPSDockerProjectRead,
+		UpdateContext: resourceHostingerVPSDockerProjectUpdate,
+		DeleteContext: resourceHostingerVPSDockerProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS running Docker Manager.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Project name, used as the docker compose project name.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`), "must contain only lowercase letters, digits, dashes and underscores"),
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "docker-compose YAML content. Use `file()` to load it from disk.",
+				ExactlyOneOf: []string{"content", "content_url"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+			},
+			"content_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of a docker-compose.yml file or of a GitHub repository containing one.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Contents of the project `.env` file.",
+			},
+			"redeploy_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value that pulls the latest images and recreates the containers whenever it changes.",
+			},
+			"containers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Containers of the project.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":     {Type: schema.TypeString, Computed: true, Description: "ID of the container."},
+						"name":   {Type: schema.TypeString, Computed: true, Description: "Name of the container."},
+						"image":  {Type: schema.TypeString, Computed: true, Description: "Image the container runs."},
+						"state":  {Type: schema.TypeString, Computed: true, Description: "State of the container (e.g., running, exited)."},
+						"status": {Type: schema.TypeString, Computed: true, Description: "Human-readable status of the container."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSDockerProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+	name := d.Get("name").(string)
+
+	if diags := deployVPSDockerProject(ctx, d, client, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", vmID, name))
+	return resourceHostingerVPSDockerProjectRead(ctx, d, m)
+}
+
+func resourceHostingerVPSDockerProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	vmID, name, err := parseVPSDockerProjectID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	containers, err := client.GetDockerProjectContainers(vmID, name)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read docker project: %w", err))
+	}
+
+	if err := d.Set("vps_id", vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set name: %w", err))
+	}
+	if err := d.Set("containers", flattenDockerContainers(containers)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set containers: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSDockerProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, name, err := parseVPSDockerProjectID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("content", "content_url", "environment") {
+		// Deploying again under the same name replaces the project
+		if diags := deployVPSDockerProject(ctx, d, client, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	} else if d.HasChange("redeploy_trigger") {
+		action, err := client.UpdateDockerProject(vmID, name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to redeploy docker project: %w", err))
+		}
+		if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for docker project %s to redeploy: %w", name, err))
+		}
+		if err := waitForDockerProjectRunning(ctx, client, vmID, name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceHostingerVPSDockerProjectRead(ctx, d, m)
+}
+
+func resourceHostingerVPSDockerProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, name, err := parseVPSDockerProjectID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	action, err := client.DeleteDockerProject(vmID, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete docker project: %w", err))
+	}
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for docker project %s to be removed: %w", name, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func deployVPSDockerProject(ctx context.Context, d *schema.ResourceData, client *HostingerClient, timeout time.Duration) diag.Diagnostics {
+	vmID := d.Get("vps_id").(int)
+	name := d.Get("name").(string)
+
+	content := d.Get("content").(string)
+	if v, ok := d.GetOk("content_url"); ok {
+		content = v.(string)
+	}
+
+	action, err := client.DeployDockerProject(vmID, DockerProjectRequest{
+		ProjectName: name,
+		Content:     content,
+		Environment: d.Get("environment").(string),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to deploy docker project: %w", err))
+	}
+
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, timeout); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for docker project %s to deploy: %w", name, err))
+	}
+	if err := waitForDockerProjectRunning(ctx, client, vmID, name, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// waitForDockerProjectRunning polls the project until it has containers and
+// all of them are running. Containers that ran to completion, such as
+// migrations or init jobs, count as done when they exited with code 0.
+func waitForDockerProjectRunning(ctx context.Context, client *HostingerClient, vmID int, name string, timeout time.Duration) error {
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		containers, err := client.GetDockerProjectContainers(vmID, name)
+		if err != nil && err != ErrNotFound {
+			return retry.NonRetryableError(err)
+		}
+		if len(containers) == 0 {
+			return retry.RetryableError(fmt.Errorf("docker project %s has no containers yet", name))
+		}
+		for _, c := range containers {
+			switch c.State {
+			case "running":
+			case "exited":
+				if code, ok := c.ExitCode(); !ok || code != 0 {
+					return retry.NonRetryableError(fmt.Errorf("container %s exited: %s", c.Name, c.Status))
+				}
+			default:
+				return retry.RetryableError(fmt.Errorf("container %s is %s", c.Name, c.State))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for docker project %s containers to be up: %w", name, err)
+	}
+	return nil
+}
+
+func flattenDockerContainers(containers []DockerContainer) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(containers))
+	for _, c := range containers {
+		result = append(result, map[string]interface{}{
+			"id":     c.ID,
+			"name":   c.Name,
+			"image":  c.Image,
+			"state":  c.State,
+			"status": c.Status,
+		})
+	}
+	return result
+}
+
+// parseVPSDockerProjectID splits a `<vm_id>/<project_name>` resource ID.
+func parseVPSDockerProjectID(id string) (int, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("unexpected ID format %q, expected <vm_id>/<project_name>", id)
+	}
+
+	vmID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid VPS ID in %q: %w", id, err)
+	}
+	return vmID, parts[1], nil
+}
+
+// HostingerClient implementations:
+
+func (c *HostingerClient) DeployDockerProject(vmID int, project DockerProjectRequest) (*Action, error) {
+	endpoint := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/docker", c.BaseURL, vmID)
+	action, err := c.doVPSAction("POST", endpoint, project)
+	if err != nil {
+		return nil, fmt.Errorf("deploy docker project failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) UpdateDockerProject(vmID int, name string) (*Action, error) {
+	endpoint := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/docker/%s/update", c.BaseURL, vmID, url.PathEscape(name))
+	action, err := c.doVPSAction("POST", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("update docker project failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) DeleteDockerProject(vmID int, name string) (*Action, error) {
+	endpoint := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/docker/%s/down", c.BaseURL, vmID, url.PathEscape(name))
+	action, err := c.doVPSAction("DELETE", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete docker project failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) GetDockerProjectContainers(vmID int, name string) ([]DockerContainer, error) {
+	endpoint := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/docker/%s/containers", c.BaseURL, vmID, url.PathEscape(name))
+	req, _ := http.NewRequest("GET", endpoint, bytes.NewBuffer(nil))
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list docker project containers failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var containers []DockerContainer
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
@@ -0,0 +1,152 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVPSDockerProjectID(t *testing.T) {
+	vmID, name, err := parseVPSDockerProjectID("42/web-app")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if vmID != 42 || name != "web-app" {
+		t.Errorf("unexpected result: %d %q", vmID, name)
+	}
+
+	for _, id := range []string{"42", "42/", "abc/web"} {
+		if _, _, err := parseVPSDockerProjectID(id); err == nil {
+			t.Errorf("expected error for %q", id)
+		}
+	}
+}
+
+func TestResourceHostingerVPSDockerProject_Create(t *testing.T) {
+	var deployed DockerProjectRequest
+	polls := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/docker":
+			if err := json.NewDecoder(r.Body).Decode(&deployed); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(Action{ID: 300, Name: "docker_compose_up", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/300":
+			_ = json.NewEncoder(w).Encode(Action{ID: 300, State: "success"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/docker/web/containers":
+			polls++
+			state := "created"
+			if polls > 1 {
+				state = "running"
+			}
+			_ = json.NewEncoder(w).Encode([]DockerContainer{
+				{ID: "abc123", Name: "web-nginx-1", Image: "nginx:latest", State: state, Status: "Up 2 seconds"},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPSDockerProject()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	_ = d.Set("vps_id", 42)
+	_ = d.Set("name", "web")
+	_ = d.Set("content", "services:\n  nginx:\n    image: nginx:latest\n")
+	_ = d.Set("environment", "FOO=bar")
+
+	if diags := resourceHostingerVPSDockerProjectCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	if deployed.ProjectName != "web" || deployed.Environment != "FOO=bar" || deployed.Content == "" {
+		t.Errorf("unexpected deploy request: %+v", deployed)
+	}
+	if d.Id() != "42/web" {
+		t.Errorf("expected ID 42/web, got %q", d.Id())
+	}
+	if got := d.Get("containers.0.state"); got != "running" {
+		t.Errorf("expected running container, got %v", got)
+	}
+}
+
+func TestResourceHostingerVPSDockerProjectRead_NotFound(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := resourceHostingerVPSDockerProject().TestResourceData()
+	d.SetId("42/web")
+
+	if diags := resourceHostingerVPSDockerProjectRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected resource to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestWaitForDockerProjectRunning_OneShotContainers(t *testing.T) {
+	tests := []struct {
+		name      string
+		migrate   DockerContainer
+		wantError string
+	}{
+		{
+			name:    "completed",
+			migrate: DockerContainer{Name: "app-migrate-1", State: "exited", Status: "Exited (0) 3 seconds ago"},
+		},
+		{
+			name:      "failed",
+			migrate:   DockerContainer{Name: "app-migrate-1", State: "exited", Status: "Exited (1) 3 seconds ago"},
+			wantError: "container app-migrate-1 exited: Exited (1) 3 seconds ago",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" || r.URL.Path != "/api/vps/v1/virtual-machines/42/docker/app/containers" {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				_ = json.NewEncoder(w).Encode([]DockerContainer{
+					{Name: "app-web-1", State: "running", Status: "Up 5 seconds"},
+					tt.migrate,
+				})
+			}))
+			defer mockServer.Close()
+
+			client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+			err := waitForDockerProjectRunning(context.Background(), client, 42, "app", 5*time.Second)
+			if tt.wantError == "" && err != nil {
+				t.Errorf("expected a completed one-shot container to count as done, got %v", err)
+			}
+			if tt.wantError != "" && (err == nil || !strings.Contains(err.Error(), tt.wantError)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceHostingerVPSFirewallAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSFirewallAttachmentCreate,
+		ReadContext:   resourceHostingerVPSFirewallAttachmentRead,
+		DeleteContext: resourceHostingerVPSFirewallAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the firewall to activate.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS to activate the firewall on.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSFirewallAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	firewallID := d.Get("firewall_id").(int)
+	vmID := d.Get("vps_id").(int)
+
+	if err := client.ActivateFirewall(firewallID, vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to activate firewall %d on VPS %d: %w", firewallID, vmID, err))
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", firewallID, vmID))
+	return resourceHostingerVPSFirewallAttachmentRead(ctx, d, m)
+}
+
+func resourceHostingerVPSFirewallAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	firewallID, vmID, err := parseFirewallAttachmentID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	// Another firewall was activated or this one deactivated outside Terraform
+	if vm.FirewallID == nil || *vm.FirewallID != firewallID {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("firewall_id", firewallID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set firewall_id: %w", err))
+	}
+	if err := d.Set("vps_id", vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSFirewallAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	firewallID := d.Get("firewall_id").(int)
+	vmID := d.Get("vps_id").(int)
+
+	if err := client.DeactivateFirewall(firewallID, vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to deactivate firewall %d on VPS %d: %w", firewallID, vmID, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parseFirewallAttachmentID splits a `<firewall_id>/<vm_id>` resource ID.
+func parseFirewallAttachmentID(id string) (int, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected ID format %q, expected <firewall_id>/<vm_id>", id)
+	}
+
+	firewallID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid firewall ID in %q: %w", id, err)
+	}
+	vmID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid VPS ID in %q: %w", id, err)
+	}
+	return firewallID, vmID, nil
+}
@@ -0,0 +1,417 @@
+package hostinger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// firewallProtocols lists the protocols accepted by the VPS firewall API.
+var firewallProtocols = []string{
+	"TCP", "UDP", "ICMP", "ICMPv6", "GRE", "ESP", "AH", "any",
+	"SSH", "HTTP", "HTTPS", "MySQL", "PostgreSQL",
+}
+
+type Firewall struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	IsSynced  bool           `json:"is_synced"`
+	Rules     []FirewallRule `json:"rules"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+type FirewallRule struct {
+	ID           int    `json:"id,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Protocol     string `json:"protocol"`
+	Port         string `json:"port"`
+	Source       string `json:"source"`
+	SourceDetail string `json:"source_detail"`
+}
+
+// key identifies a rule by its content, used to match configured rules with
+// the ones returned by the API.
+func (r FirewallRule) key() string {
+	return strings.Join([]string{strings.ToLower(r.Protocol), r.Port, r.Source, r.SourceDetail}, "|")
+}
+
+func firewallRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"protocol": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Protocol matched by the rule (e.g., `TCP`, `UDP`, `ICMP`, `SSH`, `HTTPS`, `any`).",
+			ValidateFunc: validation.StringInSlice(firewallProtocols, false),
+		},
+		"port": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "any",
+			Description: "Port or port range (`22`, `1024:2048`) matched by the rule, or `any`.",
+		},
+		"source": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "any",
+			Description:  "Traffic source: `any` or `custom`.",
+			ValidateFunc: validation.StringInSlice([]string{"any", "custom"}, false),
+		},
+		"source_detail": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "any",
+			Description: "IP address, CIDR or range allowed when `source` is `custom`.",
+		},
+	}
+}
+
+func resourceHostingerVPSFirewall() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSFirewallCreate,
+		ReadContext:   resourceHostingerVPSFirewallRead,
+		UpdateContext: resourceHostingerVPSFirewallUpdate,
+		DeleteContext: resourceHostingerVPSFirewallDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the firewall.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ConfigMode:  schema.SchemaConfigModeAttr,
+				Description: "Accept rules of the firewall. Leave unset to manage rules with `hostinger_vps_firewall_rule` instead; the two cannot be combined on the same firewall.",
+				Elem:        &schema.Resource{Schema: firewallRuleSchema()},
+			},
+			"is_synced": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the rules are in sync on every attached VPS.",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSFirewallCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	fw, err := client.CreateFirewall(d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create firewall: %w", err))
+	}
+	d.SetId(strconv.Itoa(fw.ID))
+
+	if v, ok := d.GetOk("rule"); ok {
+		for _, rule := range expandFirewallRules(v.(*schema.Set).List()) {
+			if _, err := client.CreateFirewallRule(fw.ID, rule); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to create firewall rule: %w", err))
+			}
+		}
+	}
+
+	return resourceHostingerVPSFirewallRead(ctx, d, m)
+}
+
+func resourceHostingerVPSFirewallRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid firewall ID: %s", d.Id()))
+	}
+
+	fw, err := client.GetFirewall(id)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read firewall: %w", err))
+	}
+
+	if err := d.Set("name", fw.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set name: %w", err))
+	}
+	if err := d.Set("rule", flattenFirewallRules(fw.Rules)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set rule: %w", err))
+	}
+	if err := d.Set("is_synced", fw.IsSynced); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set is_synced: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSFirewallUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	id, _ := strconv.Atoi(d.Id())
+
+	if d.HasChange("rule") {
+		fw, err := client.GetFirewall(id)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read firewall: %w", err))
+		}
+
+		desired := map[string]FirewallRule{}
+		for _, rule := range expandFirewallRules(d.Get("rule").(*schema.Set).List()) {
+			desired[rule.key()] = rule
+		}
+
+		// Remove rules that are no longer configured, keep the ones that are
+		for _, rule := range fw.Rules {
+			if _, ok := desired[rule.key()]; ok {
+				delete(desired, rule.key())
+				continue
+			}
+			if err := client.DeleteFirewallRule(id, rule.ID); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to delete firewall rule %d: %w", rule.ID, err))
+			}
+		}
+
+		for _, rule := range desired {
+			if _, err := client.CreateFirewallRule(id, rule); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to create firewall rule: %w", err))
+			}
+		}
+
+		if err := client.SyncFirewallOnAttachedVMs(id); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to sync firewall rules: %w", err))
+		}
+	}
+
+	return resourceHostingerVPSFirewallRead(ctx, d, m)
+}
+
+func resourceHostingerVPSFirewallDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	id, _ := strconv.Atoi(d.Id())
+
+	if err := client.DeleteFirewall(id); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete firewall: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandFirewallRules(raw []interface{}) []FirewallRule {
+	rules := make([]FirewallRule, 0, len(raw))
+	for _, r := range raw {
+		rule := r.(map[string]interface{})
+		rules = append(rules, FirewallRule{
+			Protocol:     rule["protocol"].(string),
+			Port:         rule["port"].(string),
+			Source:       rule["source"].(string),
+			SourceDetail: rule["source_detail"].(string),
+		})
+	}
+	return rules
+}
+
+func flattenFirewallRules(rules []FirewallRule) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, map[string]interface{}{
+			"protocol":      rule.Protocol,
+			"port":          rule.Port,
+			"source":        rule.Source,
+			"source_detail": rule.SourceDetail,
+		})
+	}
+	return result
+}
+
+// HostingerClient implementations:
+
+func (c *HostingerClient) CreateFirewall(name string) (*Firewall, error) {
+	url := c.BaseURL + "/api/vps/v1/firewall"
+	body := map[string]string{"name": name}
+	data, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create firewall failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var fw Firewall
+	if err := json.NewDecoder(resp.Body).Decode(&fw); err != nil {
+		return nil, err
+	}
+	return &fw, nil
+}
+
+func (c *HostingerClient) GetFirewall(id int) (*Firewall, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d", c.BaseURL, id)
+	req, _ := http.NewRequest("GET", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("read firewall failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var fw Firewall
+	if err := json.NewDecoder(resp.Body).Decode(&fw); err != nil {
+		return nil, err
+	}
+	return &fw, nil
+}
+
+func (c *HostingerClient) DeleteFirewall(id int) error {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d", c.BaseURL, id)
+	req, _ := http.NewRequest("DELETE", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete firewall failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+func (c *HostingerClient) CreateFirewallRule(firewallID int, rule FirewallRule) (*FirewallRule, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d/rules", c.BaseURL, firewallID)
+	return c.sendFirewallRule("POST", url, rule)
+}
+
+func (c *HostingerClient) UpdateFirewallRule(firewallID int, rule FirewallRule) (*FirewallRule, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d/rules/%d", c.BaseURL, firewallID, rule.ID)
+	return c.sendFirewallRule("PUT", url, rule)
+}
+
+func (c *HostingerClient) sendFirewallRule(method, url string, rule FirewallRule) (*FirewallRule, error) {
+	body := map[string]string{
+		"protocol":      rule.Protocol,
+		"port":          rule.Port,
+		"source":        rule.Source,
+		"source_detail": rule.SourceDetail,
+	}
+	data, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(data))
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("save firewall rule failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var res FirewallRule
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *HostingerClient) DeleteFirewallRule(firewallID, ruleID int) error {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d/rules/%d", c.BaseURL, firewallID, ruleID)
+	req, _ := http.NewRequest("DELETE", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete firewall rule failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// ActivateFirewall applies the firewall to a VPS. A VPS has at most one active firewall.
+func (c *HostingerClient) ActivateFirewall(firewallID, vmID int) error {
+	return c.firewallVMAction("activate", firewallID, vmID)
+}
+
+func (c *HostingerClient) DeactivateFirewall(firewallID, vmID int) error {
+	return c.firewallVMAction("deactivate", firewallID, vmID)
+}
+
+// SyncFirewall pushes the current firewall rules to a VPS it is active on.
+func (c *HostingerClient) SyncFirewall(firewallID, vmID int) error {
+	return c.firewallVMAction("sync", firewallID, vmID)
+}
+
+func (c *HostingerClient) firewallVMAction(action string, firewallID, vmID int) error {
+	url := fmt.Sprintf("%s/api/vps/v1/firewall/%d/%s/%d", c.BaseURL, firewallID, action, vmID)
+	req, _ := http.NewRequest("POST", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s firewall failed (HTTP %d): %s", action, resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// SyncFirewallOnAttachedVMs syncs the firewall on every VPS it is active on,
+// so rule changes take effect immediately.
+func (c *HostingerClient) SyncFirewallOnAttachedVMs(firewallID int) error {
+	vms, err := c.GetVirtualMachines()
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if vm.FirewallID != nil && *vm.FirewallID == firewallID {
+			if err := c.SyncFirewall(firewallID, vm.ID); err != nil {
+				return fmt.Errorf("VPS %d: %w", vm.ID, err)
+			}
+		}
+	}
+	return nil
+}
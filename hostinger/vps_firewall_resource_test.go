@@ -0,0 +1,168 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeFirewallAPI is an in-memory stand-in for the VPS firewall endpoints.
+type fakeFirewallAPI struct {
+	t        *testing.T
+	firewall Firewall
+	nextRule int
+	vmFWID   *int
+	synced   []int
+}
+
+func (f *fakeFirewallAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fwPath := fmt.Sprintf("/api/vps/v1/firewall/%d", f.firewall.ID)
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/api/vps/v1/firewall":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.firewall.Name = body["name"]
+		_ = json.NewEncoder(w).Encode(f.firewall)
+	case r.Method == "GET" && r.URL.Path == fwPath:
+		_ = json.NewEncoder(w).Encode(f.firewall)
+	case r.Method == "POST" && r.URL.Path == fwPath+"/rules":
+		var rule FirewallRule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		f.nextRule++
+		rule.ID = f.nextRule
+		f.firewall.Rules = append(f.firewall.Rules, rule)
+		_ = json.NewEncoder(w).Encode(rule)
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, fwPath+"/rules/"):
+		var rule FirewallRule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		for i := range f.firewall.Rules {
+			if fmt.Sprintf("%s/rules/%d", fwPath, f.firewall.Rules[i].ID) == r.URL.Path {
+				rule.ID = f.firewall.Rules[i].ID
+				f.firewall.Rules[i] = rule
+			}
+		}
+		_ = json.NewEncoder(w).Encode(rule)
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, fwPath+"/rules/"):
+		kept := []FirewallRule{}
+		for _, rule := range f.firewall.Rules {
+			if fmt.Sprintf("%s/rules/%d", fwPath, rule.ID) != r.URL.Path {
+				kept = append(kept, rule)
+			}
+		}
+		f.firewall.Rules = kept
+	case r.Method == "POST" && r.URL.Path == fwPath+"/activate/42":
+		id := f.firewall.ID
+		f.vmFWID = &id
+	case r.Method == "POST" && r.URL.Path == fwPath+"/deactivate/42":
+		f.vmFWID = nil
+	case r.Method == "POST" && r.URL.Path == fwPath+"/sync/42":
+		f.synced = append(f.synced, 42)
+	case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines":
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 42, "firewall_group_id": f.vmFWID},
+			{"id": 43},
+		})
+	case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "firewall_group_id": f.vmFWID})
+	default:
+		f.t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}
+}
+
+func TestResourceHostingerVPSFirewall_Schema(t *testing.T) {
+	fw := resourceHostingerVPSFirewall()
+	if err := fw.InternalValidate(fw.Schema, true); err != nil {
+		t.Fatalf("firewall schema validation failed: %s", err)
+	}
+	rule := resourceHostingerVPSFirewallRule()
+	if err := rule.InternalValidate(rule.Schema, true); err != nil {
+		t.Fatalf("firewall rule schema validation failed: %s", err)
+	}
+	attachment := resourceHostingerVPSFirewallAttachment()
+	if err := attachment.InternalValidate(attachment.Schema, true); err != nil {
+		t.Fatalf("firewall attachment schema validation failed: %s", err)
+	}
+}
+
+func TestResourceHostingerVPSFirewall_RulesAndAttachment(t *testing.T) {
+	api := &fakeFirewallAPI{t: t, firewall: Firewall{ID: 5}}
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+	ctx := context.Background()
+
+	fw := resourceHostingerVPSFirewall().TestResourceData()
+	_ = fw.Set("name", "web")
+	_ = fw.Set("rule", []interface{}{
+		map[string]interface{}{"protocol": "SSH", "port": "22", "source": "custom", "source_detail": "192.0.2.0/24"},
+	})
+	if diags := resourceHostingerVPSFirewallCreate(ctx, fw, client); diags.HasError() {
+		t.Fatalf("firewall create failed: %v", diags)
+	}
+	if fw.Id() != "5" || len(api.firewall.Rules) != 1 {
+		t.Fatalf("unexpected firewall after create: id=%q rules=%v", fw.Id(), api.firewall.Rules)
+	}
+
+	attachment := resourceHostingerVPSFirewallAttachment().TestResourceData()
+	_ = attachment.Set("firewall_id", 5)
+	_ = attachment.Set("vps_id", 42)
+	if diags := resourceHostingerVPSFirewallAttachmentCreate(ctx, attachment, client); diags.HasError() {
+		t.Fatalf("attachment create failed: %v", diags)
+	}
+	if attachment.Id() != "5/42" {
+		t.Fatalf("unexpected attachment ID %q", attachment.Id())
+	}
+
+	rule := resourceHostingerVPSFirewallRule().TestResourceData()
+	_ = rule.Set("firewall_id", 5)
+	_ = rule.Set("protocol", "HTTPS")
+	_ = rule.Set("port", "443")
+	_ = rule.Set("source", "any")
+	_ = rule.Set("source_detail", "any")
+	if diags := resourceHostingerVPSFirewallRuleCreate(ctx, rule, client); diags.HasError() {
+		t.Fatalf("rule create failed: %v", diags)
+	}
+	if rule.Id() != "5/2" {
+		t.Errorf("unexpected rule ID %q", rule.Id())
+	}
+	if len(api.synced) != 1 {
+		t.Errorf("expected rule change to sync the attached VPS once, got %v", api.synced)
+	}
+
+	// A rule removed outside Terraform is dropped from state on refresh
+	api.firewall.Rules = api.firewall.Rules[:1]
+	if diags := resourceHostingerVPSFirewallRuleRead(ctx, rule, client); diags.HasError() {
+		t.Fatalf("rule read failed: %v", diags)
+	}
+	if rule.Id() != "" {
+		t.Errorf("expected deleted rule to be removed from state")
+	}
+
+	// Deactivating outside Terraform removes the attachment from state
+	api.vmFWID = nil
+	if diags := resourceHostingerVPSFirewallAttachmentRead(ctx, attachment, client); diags.HasError() {
+		t.Fatalf("attachment read failed: %v", diags)
+	}
+	if attachment.Id() != "" {
+		t.Errorf("expected deactivated attachment to be removed from state")
+	}
+}
+
+func TestParseFirewallRuleID(t *testing.T) {
+	firewallID, ruleID, err := parseFirewallRuleID("5/12")
+	if err != nil || firewallID != 5 || ruleID != 12 {
+		t.Errorf("unexpected result: %d %d %v", firewallID, ruleID, err)
+	}
+	if _, _, err := parseFirewallRuleID("5"); err == nil {
+		t.Errorf("expected error for malformed ID")
+	}
+}
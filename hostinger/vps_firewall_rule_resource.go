@@ -0,0 +1,162 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceHostingerVPSFirewallRule() *schema.Resource {
+	ruleSchema := firewallRuleSchema()
+	ruleSchema["firewall_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		Description:  "ID of the firewall the rule belongs to.",
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSFirewallRuleCreate,
+		ReadContext:   resourceHostingerVPSFirewallRuleRead,
+		UpdateContext: resourceHostingerVPSFirewallRuleUpdate,
+		DeleteContext: resourceHostingerVPSFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: ruleSchema,
+	}
+}
+
+func resourceHostingerVPSFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	firewallID := d.Get("firewall_id").(int)
+
+	rule, err := client.CreateFirewallRule(firewallID, firewallRuleFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create firewall rule: %w", err))
+	}
+	d.SetId(fmt.Sprintf("%d/%d", firewallID, rule.ID))
+
+	if err := client.SyncFirewallOnAttachedVMs(firewallID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to sync firewall rules: %w", err))
+	}
+
+	return resourceHostingerVPSFirewallRuleRead(ctx, d, m)
+}
+
+func resourceHostingerVPSFirewallRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	firewallID, ruleID, err := parseFirewallRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fw, err := client.GetFirewall(firewallID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read firewall: %w", err))
+	}
+
+	for _, rule := range fw.Rules {
+		if rule.ID != ruleID {
+			continue
+		}
+		if err := d.Set("firewall_id", firewallID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set firewall_id: %w", err))
+		}
+		if err := d.Set("protocol", rule.Protocol); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set protocol: %w", err))
+		}
+		if err := d.Set("port", rule.Port); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set port: %w", err))
+		}
+		if err := d.Set("source", rule.Source); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set source: %w", err))
+		}
+		if err := d.Set("source_detail", rule.SourceDetail); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set source_detail: %w", err))
+		}
+		return nil
+	}
+
+	d.SetId("") // rule removed outside Terraform
+	return nil
+}
+
+func resourceHostingerVPSFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	firewallID, ruleID, err := parseFirewallRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule := firewallRuleFromResourceData(d)
+	rule.ID = ruleID
+	if _, err := client.UpdateFirewallRule(firewallID, rule); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to update firewall rule: %w", err))
+	}
+
+	if err := client.SyncFirewallOnAttachedVMs(firewallID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to sync firewall rules: %w", err))
+	}
+
+	return resourceHostingerVPSFirewallRuleRead(ctx, d, m)
+}
+
+func resourceHostingerVPSFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	firewallID, ruleID, err := parseFirewallRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.DeleteFirewallRule(firewallID, ruleID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete firewall rule: %w", err))
+	}
+
+	if err := client.SyncFirewallOnAttachedVMs(firewallID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to sync firewall rules: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func firewallRuleFromResourceData(d *schema.ResourceData) FirewallRule {
+	return FirewallRule{
+		Protocol:     d.Get("protocol").(string),
+		Port:         d.Get("port").(string),
+		Source:       d.Get("source").(string),
+		SourceDetail: d.Get("source_detail").(string),
+	}
+}
+
+// parseFirewallRuleID splits a `<firewall_id>/<rule_id>` resource ID.
+func parseFirewallRuleID(id string) (int, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected ID format %q, expected <firewall_id>/<rule_id>", id)
+	}
+
+	firewallID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid firewall ID in %q: %w", id, err)
+	}
+	ruleID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rule ID in %q: %w", id, err)
+	}
+	return firewallID, ruleID, nil
+}
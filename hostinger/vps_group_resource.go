@@ -0,0 +1,678 @@
+package hostinger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const vpsGroupMaxParallelism = 10
+
+var (
+	vpsGroupIndexVerb = regexp.MustCompile(`%0?[1-9]?d`)
+	vpsGroupFQDN      = regexp.MustCompile(`^([a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}$`)
+)
+
+// vpsGroupInstance is a member of a hostinger_vps_group, as kept in state.
+type vpsGroupInstance struct {
+	Index      int
+	VPSID      int
+	Hostname   string
+	TemplateID int
+	Status     string
+	IPv4       string
+	IPv6       string
+}
+
+func resourceHostingerVPSGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSGroupCreate,
+		ReadContext:   resourceHostingerVPSGroupRead,
+		UpdateContext: resourceHostingerVPSGroupUpdate,
+		DeleteContext: resourceHostingerVPSGroupDelete,
+		CustomizeDiff: customdiff.All(
+			resourceHostingerVPSCustomizeDiff,
+			resourceHostingerVPSGroupCustomizeDiff,
+			customdiff.ComputedIf("instances", func(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+				return d.HasChanges("size", "template_id", "hostname_pattern")
+			}),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"size": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Number of VPS instances in the group. Growing purchases new instances, shrinking releases the ones with the highest index.",
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"hostname_pattern": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Hostname of each instance, with one integer verb replaced by its 1-based index (e.g., `worker-%02d.example.com`).",
+				ValidateFunc: validateVPSGroupHostnamePattern,
+			},
+			"plan": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "VPS plan identifier (e.g., `hostingercom-vps-kvm2-usd-1m`).",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"data_center_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Data center location identifier for every instance.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"template_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "OS template ID. Changing it reinstalls the instances in batches of `max_unavailable`.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Root password of every instance. If not set, one is generated for each. Changing it rotates the password of every instance in place.",
+				ValidateFunc: validateVPSPassword,
+			},
+			"payment_method_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Payment method ID to use for the orders. If omitted, the default method will be used. It cannot be changed once the group exists.",
+			},
+			"post_install_script_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the post-install script to run after OS setup and after every reinstall. Changing it does not touch running instances, it takes effect on new instances and on the next reinstall.",
+			},
+			"ssh_key_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "List of SSH key IDs to attach to every instance. Keys can be added but not removed, since the API cannot detach a key from a VPS.",
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				Description:  "Maximum number of purchases or releases in flight at once.",
+				ValidateFunc: validation.IntBetween(1, vpsGroupMaxParallelism),
+			},
+			"max_unavailable": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Number of instances reinstalled at once when `template_id` changes. The next batch starts once the previous one is running again.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      vpsOnDestroyCancel,
+				Description:  "Action taken on the subscription of each released instance: `cancel`, `disable_auto_renew` or `forget`.",
+				ValidateFunc: validation.StringInSlice([]string{vpsOnDestroyCancel, vpsOnDestroyDisableAutoRenew, vpsOnDestroyForget}, false),
+			},
+			// Output attributes:
+			"instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Instances of the group, ordered by index.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index":        {Type: schema.TypeInt, Computed: true},
+						"vps_id":       {Type: schema.TypeInt, Computed: true},
+						"hostname":     {Type: schema.TypeString, Computed: true},
+						"template_id":  {Type: schema.TypeInt, Computed: true},
+						"status":       {Type: schema.TypeString, Computed: true},
+						"ipv4_address": {Type: schema.TypeString, Computed: true},
+						"ipv6_address": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"vps_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the instances, ordered by index.",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	// The plan only checks values known at plan time
+	if err := validateVPSPlan(client, d.Get("plan").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateVPSTemplateAndDataCenter(client, d.Get("template_id").(int), d.Get("data_center_id").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	indexes := make([]int, d.Get("size").(int))
+	for i := range indexes {
+		indexes[i] = i + 1
+	}
+
+	instances, err := purchaseVPSGroupInstances(client, d, indexes)
+	if len(instances) == 0 {
+		return diag.FromErr(err)
+	}
+
+	// Whatever was bought is billed, keep it in state even when some purchases
+	// failed. Failing here would taint the group and the next apply would
+	// release the instances that were bought, so the failures are reported as
+	// warnings and the next apply buys the missing indexes.
+	d.SetId(id.UniqueId())
+	if diags := setVPSGroupInstances(d, instances); diags.HasError() {
+		return diags
+	}
+	diags := resourceHostingerVPSGroupRead(ctx, d, m)
+	return append(vpsGroupWarnings(err), diags...)
+}
+
+func resourceHostingerVPSGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	var instances []vpsGroupInstance
+	for _, inst := range expandVPSGroupInstances(d.Get("instances").([]interface{})) {
+		vm, err := client.GetVirtualMachine(inst.VPSID)
+		if err != nil {
+			if err == ErrNotFound {
+				// Dropped here so that the next apply replaces it
+				continue
+			}
+			return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", inst.VPSID, err))
+		}
+		vm.resolveIDs()
+
+		inst.Hostname = vm.Hostname
+		inst.Status = vm.State
+		if vm.TemplateID != 0 {
+			inst.TemplateID = vm.TemplateID
+		}
+		inst.IPv4, inst.IPv6 = "", ""
+		if len(vm.IPv4) > 0 {
+			inst.IPv4 = vm.IPv4[0].Address
+		}
+		if len(vm.IPv6) > 0 {
+			inst.IPv6 = vm.IPv6[0].Address
+		}
+		instances = append(instances, inst)
+	}
+
+	if len(instances) == 0 {
+		d.SetId("")
+		return nil
+	}
+	if err := d.Set("size", len(instances)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set size: %w", err))
+	}
+
+	// An instance reinstalled outside Terraform, or left behind by a failed
+	// rollout, shows up as a template_id change so that the next apply fixes it
+	templateID := d.Get("template_id").(int)
+	for _, inst := range instances {
+		if inst.TemplateID != 0 && inst.TemplateID != templateID {
+			templateID = inst.TemplateID
+			break
+		}
+	}
+	if err := d.Set("template_id", templateID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set template_id: %w", err))
+	}
+	return setVPSGroupInstances(d, instances)
+}
+
+func resourceHostingerVPSGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	// instances is unknown in the plan whenever the group changes, start from state
+	current, _ := d.GetChange("instances")
+	instances := expandVPSGroupInstances(current.([]interface{}))
+	size := d.Get("size").(int)
+
+	// On failure the group is saved as far as it got, and the attributes
+	// whose change did not go through are reset so that the next plan retries.
+	fail := func(err error, resets map[string]interface{}) diag.Diagnostics {
+		diags := setVPSGroupInstances(d, instances)
+		for k, v := range resets {
+			if err := d.Set(k, v); err != nil {
+				diags = append(diags, diag.FromErr(fmt.Errorf("failed to set %s: %w", k, err))...)
+			}
+		}
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	// Shrink first, releasing the highest indexes
+	var keep, release []vpsGroupInstance
+	for _, inst := range instances {
+		if inst.Index > size {
+			release = append(release, inst)
+		} else {
+			keep = append(keep, inst)
+		}
+	}
+	if len(release) > 0 {
+		released, err := releaseVPSGroupInstances(client, release, d.Get("on_destroy").(string), d.Get("parallelism").(int))
+		instances = keep
+		for i, inst := range release {
+			if !released[i] {
+				instances = append(instances, inst)
+			}
+		}
+		sortVPSGroupInstances(instances)
+		if err != nil {
+			return fail(err, map[string]interface{}{"size": len(instances)})
+		}
+	}
+
+	if d.HasChange("hostname_pattern") {
+		pattern := d.Get("hostname_pattern").(string)
+		for i, inst := range instances {
+			hostname := vpsGroupHostname(pattern, inst.Index)
+			if err := client.UpdateHostname(inst.VPSID, hostname); err != nil {
+				old, _ := d.GetChange("hostname_pattern")
+				return fail(fmt.Errorf("failed to update hostname of VPS %d: %w", inst.VPSID, err), map[string]interface{}{"hostname_pattern": old})
+			}
+			instances[i].Hostname = hostname
+		}
+	}
+
+	if d.HasChange("ssh_key_ids") {
+		keyIDs := expandVPSGroupSSHKeyIDs(d)
+		for _, inst := range instances {
+			if err := attachMissingSSHKeys(client, inst.VPSID, keyIDs); err != nil {
+				old, _ := d.GetChange("ssh_key_ids")
+				return fail(err, map[string]interface{}{"ssh_key_ids": old})
+			}
+		}
+	}
+
+	// A reinstall already applies the password, so only rotate it on instances
+	// that keep their template
+	if d.HasChange("password") {
+		if password, ok := d.GetOk("password"); ok {
+			templateID := d.Get("template_id").(int)
+			for _, inst := range instances {
+				if inst.TemplateID != templateID {
+					continue
+				}
+				if err := client.SetRootPassword(inst.VPSID, password.(string)); err != nil {
+					old, _ := d.GetChange("password")
+					return fail(fmt.Errorf("failed to update root password of VPS %d: %w", inst.VPSID, err), map[string]interface{}{"password": old})
+				}
+			}
+		}
+	}
+
+	// Grow before rolling so that new capacity is up while old instances reinstall
+	present := make(map[int]bool, len(instances))
+	for _, inst := range instances {
+		present[inst.Index] = true
+	}
+	var missing []int
+	for i := 1; i <= size; i++ {
+		if !present[i] {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) > 0 {
+		bought, err := purchaseVPSGroupInstances(client, d, missing)
+		instances = append(instances, bought...)
+		sortVPSGroupInstances(instances)
+		if err != nil {
+			return fail(err, map[string]interface{}{"size": len(instances)})
+		}
+	}
+
+	if err := rollVPSGroupTemplate(ctx, client, d, instances); err != nil {
+		old, _ := d.GetChange("template_id")
+		return fail(err, map[string]interface{}{"template_id": old})
+	}
+
+	if diags := setVPSGroupInstances(d, instances); diags.HasError() {
+		return diags
+	}
+	return resourceHostingerVPSGroupRead(ctx, d, m)
+}
+
+func resourceHostingerVPSGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	instances := expandVPSGroupInstances(d.Get("instances").([]interface{}))
+
+	released, err := releaseVPSGroupInstances(client, instances, d.Get("on_destroy").(string), d.Get("parallelism").(int))
+	if err != nil {
+		// Keep the instances that are still billed so that a retry only releases those
+		var remaining []vpsGroupInstance
+		for i, inst := range instances {
+			if !released[i] {
+				remaining = append(remaining, inst)
+			}
+		}
+		if diags := setVPSGroupInstances(d, remaining); diags.HasError() {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceHostingerVPSGroupCustomizeDiff rejects the changes Update cannot
+// apply to existing instances.
+func resourceHostingerVPSGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("payment_method_id") && d.NewValueKnown("payment_method_id") {
+		return fmt.Errorf("payment_method_id cannot be changed on an existing group, it is only used to purchase its instances")
+	}
+
+	if d.HasChange("ssh_key_ids") && d.NewValueKnown("ssh_key_ids") {
+		oldKeys, newKeys := d.GetChange("ssh_key_ids")
+		wanted := make(map[int]bool)
+		for _, v := range newKeys.([]interface{}) {
+			wanted[v.(int)] = true
+		}
+		for _, v := range oldKeys.([]interface{}) {
+			if !wanted[v.(int)] {
+				return fmt.Errorf("SSH key %d cannot be removed from ssh_key_ids, the API cannot detach a key from a VPS", v.(int))
+			}
+		}
+	}
+	return nil
+}
+
+// purchaseVPSGroupInstances buys one VPS per index, at most `parallelism` at
+// a time. It returns the instances that were bought along with the errors of
+// the purchases that failed.
+func purchaseVPSGroupInstances(client *HostingerClient, d *schema.ResourceData, indexes []int) ([]vpsGroupInstance, error) {
+	plan := d.Get("plan").(string)
+	pattern := d.Get("hostname_pattern").(string)
+	templateID := d.Get("template_id").(int)
+	keyIDs := expandVPSGroupSSHKeyIDs(d)
+
+	setup := PurchaseVPSSetup{
+		DataCenterID: d.Get("data_center_id").(int),
+		TemplateID:   templateID,
+	}
+	if v, ok := d.GetOk("password"); ok {
+		pw := v.(string)
+		setup.Password = &pw
+	}
+	if v, ok := d.GetOk("post_install_script_id"); ok {
+		id := v.(int)
+		setup.PostInstallScriptID = &id
+	}
+	var paymentMethodID *int
+	if v, ok := d.GetOk("payment_method_id"); ok {
+		id := v.(int)
+		paymentMethodID = &id
+	}
+
+	bought := make([]*vpsGroupInstance, len(indexes))
+	err := runVPSGroupBounded(len(indexes), d.Get("parallelism").(int), func(i int) error {
+		hostname := vpsGroupHostname(pattern, indexes[i])
+		instanceSetup := setup
+		instanceSetup.Hostname = &hostname
+
+		res, err := client.PurchaseVPS(PurchaseVPSRequest{
+			ItemID:          plan,
+			PaymentMethodID: paymentMethodID,
+			Setup:           instanceSetup,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to purchase VPS %s: %w", hostname, err)
+		}
+		vmID := res.VirtualMachine.ID
+		bought[i] = &vpsGroupInstance{Index: indexes[i], VPSID: vmID, Hostname: hostname, TemplateID: templateID}
+
+		if len(keyIDs) > 0 {
+			if err := client.AttachSSHKeysToVM(vmID, keyIDs); err != nil {
+				return fmt.Errorf("failed to attach SSH keys to VPS %d: %w", vmID, err)
+			}
+		}
+		return nil
+	})
+
+	var instances []vpsGroupInstance
+	for _, inst := range bought {
+		if inst != nil {
+			instances = append(instances, *inst)
+		}
+	}
+	return instances, err
+}
+
+// releaseVPSGroupInstances releases the subscriptions of the instances, at
+// most `parallelism` at a time, and reports which of them were released.
+func releaseVPSGroupInstances(client *HostingerClient, instances []vpsGroupInstance, onDestroy string, parallelism int) ([]bool, error) {
+	released := make([]bool, len(instances))
+	err := runVPSGroupBounded(len(instances), parallelism, func(i int) error {
+		if err := releaseVPS(client, instances[i].VPSID, onDestroy); err != nil {
+			return err
+		}
+		released[i] = true
+		return nil
+	})
+	return released, err
+}
+
+// rollVPSGroupTemplate reinstalls the instances that do not run template_id,
+// max_unavailable at a time, and waits for each batch to be running again
+// before starting the next one. Instances are updated in place so that the
+// caller knows how far the rollout got when it fails.
+func rollVPSGroupTemplate(ctx context.Context, client *HostingerClient, d *schema.ResourceData, instances []vpsGroupInstance) error {
+	templateID := d.Get("template_id").(int)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	var password *string
+	if v, ok := d.GetOk("password"); ok {
+		pw := v.(string)
+		password = &pw
+	}
+	var postScriptID *int
+	if v, ok := d.GetOk("post_install_script_id"); ok {
+		id := v.(int)
+		postScriptID = &id
+	}
+
+	var pending []int
+	for i, inst := range instances {
+		if inst.TemplateID != templateID {
+			pending = append(pending, i)
+		}
+	}
+
+	batchSize := d.Get("max_unavailable").(int)
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		err := runVPSGroupBounded(len(batch), len(batch), func(i int) error {
+			inst := &instances[batch[i]]
+			action, err := client.RecreateVirtualMachine(inst.VPSID, templateID, password, postScriptID)
+			if err != nil {
+				return fmt.Errorf("failed to reinstall VPS %d: %w", inst.VPSID, err)
+			}
+			if err := waitForVPSAction(ctx, client, inst.VPSID, action.ID, timeout); err != nil {
+				return fmt.Errorf("error waiting for VPS %d to be reinstalled: %w", inst.VPSID, err)
+			}
+			if _, err := waitForVPSState(ctx, client, inst.VPSID, timeout, vpsStateRunning); err != nil {
+				return fmt.Errorf("error waiting for VPS %d to run: %w", inst.VPSID, err)
+			}
+			inst.TemplateID = templateID
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runVPSGroupBounded calls fn for 0..n-1 with at most limit calls in flight
+// and joins the errors. Every call runs even when some of them fail, since
+// aborting half way would leave orders in an unknown state.
+func runVPSGroupBounded(n, limit int, fn func(i int) error) error {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// attachMissingSSHKeys attaches the keys that are not attached to the VPS yet.
+func attachMissingSSHKeys(client *HostingerClient, vmID int, keyIDs []int) error {
+	current, err := client.GetSSHKeyIDsForVM(vmID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing SSH keys of VPS %d: %w", vmID, err)
+	}
+	attached := make(map[int]bool, len(current))
+	for _, id := range current {
+		attached[id] = true
+	}
+
+	var toAttach []int
+	for _, id := range keyIDs {
+		if !attached[id] {
+			toAttach = append(toAttach, id)
+		}
+	}
+	if len(toAttach) == 0 {
+		return nil
+	}
+	if err := client.AttachSSHKeysToVM(vmID, toAttach); err != nil {
+		return fmt.Errorf("failed to attach SSH keys to VPS %d: %w", vmID, err)
+	}
+	return nil
+}
+
+func validateVPSGroupHostnamePattern(i interface{}, k string) ([]string, []error) {
+	pattern, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if n := len(vpsGroupIndexVerb.FindAllString(pattern, -1)); n != 1 || strings.Count(pattern, "%") != 1 {
+		return nil, []error{fmt.Errorf("%s must contain exactly one integer verb such as %%d or %%02d, got %q", k, pattern)}
+	}
+	if hostname := vpsGroupHostname(pattern, 1); !vpsGroupFQDN.MatchString(hostname) {
+		return nil, []error{fmt.Errorf("%s must produce a valid FQDN, got %q", k, hostname)}
+	}
+	return nil, nil
+}
+
+func vpsGroupHostname(pattern string, index int) string {
+	return fmt.Sprintf(pattern, index)
+}
+
+// vpsGroupWarnings turns the joined errors of a bounded run into one warning
+// per failed call.
+func vpsGroupWarnings(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	var diags diag.Diagnostics
+	for _, e := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  e.Error(),
+			Detail:   "The instance is purchased by the next apply.",
+		})
+	}
+	return diags
+}
+
+func sortVPSGroupInstances(instances []vpsGroupInstance) {
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Index < instances[j].Index })
+}
+
+func expandVPSGroupSSHKeyIDs(d *schema.ResourceData) []int {
+	raw := d.Get("ssh_key_ids").([]interface{})
+	ids := make([]int, len(raw))
+	for i, v := range raw {
+		ids[i] = v.(int)
+	}
+	return ids
+}
+
+func expandVPSGroupInstances(raw []interface{}) []vpsGroupInstance {
+	instances := make([]vpsGroupInstance, 0, len(raw))
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		instances = append(instances, vpsGroupInstance{
+			Index:      m["index"].(int),
+			VPSID:      m["vps_id"].(int),
+			Hostname:   m["hostname"].(string),
+			TemplateID: m["template_id"].(int),
+			Status:     m["status"].(string),
+			IPv4:       m["ipv4_address"].(string),
+			IPv6:       m["ipv6_address"].(string),
+		})
+	}
+	sortVPSGroupInstances(instances)
+	return instances
+}
+
+func setVPSGroupInstances(d *schema.ResourceData, instances []vpsGroupInstance) diag.Diagnostics {
+	flat := make([]interface{}, len(instances))
+	ids := make([]int, len(instances))
+	for i, inst := range instances {
+		flat[i] = map[string]interface{}{
+			"index":        inst.Index,
+			"vps_id":       inst.VPSID,
+			"hostname":     inst.Hostname,
+			"template_id":  inst.TemplateID,
+			"status":       inst.Status,
+			"ipv4_address": inst.IPv4,
+			"ipv6_address": inst.IPv6,
+		}
+		ids[i] = inst.VPSID
+	}
+	if err := d.Set("instances", flat); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set instances: %w", err))
+	}
+	if err := d.Set("vps_ids", ids); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_ids: %w", err))
+	}
+	return nil
+}
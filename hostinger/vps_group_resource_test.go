@@ -0,0 +1,484 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeVPSGroupAPI serves the purchase, reinstall and billing endpoints used
+// by hostinger_vps_group and records how many calls overlapped.
+type fakeVPSGroupAPI struct {
+	t          *testing.T
+	mu         sync.Mutex
+	nextID     int
+	vms        map[int]*VirtualMachine
+	failHosts  map[string]bool
+	failVMs    map[int]bool
+	purchasing int
+	maxBuying  int
+	installing map[int]bool
+	maxInstall int
+	recreated  []int
+	passwords  map[int]string
+	cancelled  []string
+}
+
+func newFakeVPSGroupAPI(t *testing.T) *fakeVPSGroupAPI {
+	return &fakeVPSGroupAPI{
+		t:          t,
+		nextID:     100,
+		vms:        map[int]*VirtualMachine{},
+		failHosts:  map[string]bool{},
+		failVMs:    map[int]bool{},
+		installing: map[int]bool{},
+		passwords:  map[int]string{},
+	}
+}
+
+func (f *fakeVPSGroupAPI) addVM(id, templateID int, hostname string) {
+	f.vms[id] = &VirtualMachine{ID: id, Hostname: hostname, State: vpsStateRunning, TemplateID: templateID, SubscriptionID: fmt.Sprintf("sub-%d", id)}
+}
+
+func (f *fakeVPSGroupAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if serveVPSCatalog(w, r) {
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/api/vps/v1/virtual-machines")
+	switch {
+	case r.Method == "POST" && path == "":
+		var req PurchaseVPSRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			f.t.Errorf("failed to decode purchase request: %v", err)
+		}
+		f.mu.Lock()
+		f.purchasing++
+		f.maxBuying = max(f.maxBuying, f.purchasing)
+		f.mu.Unlock()
+
+		// Give other purchases the chance to overlap
+		time.Sleep(20 * time.Millisecond)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.purchasing--
+		if f.failHosts[*req.Setup.Hostname] {
+			http.Error(w, `{"message": "payment failed"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		f.nextID++
+		f.addVM(f.nextID, req.Setup.TemplateID, *req.Setup.Hostname)
+		_ = json.NewEncoder(w).Encode(PurchaseVPSResponse{VirtualMachine: VirtualMachine{ID: f.nextID}})
+
+	case r.Method == "POST" && strings.HasSuffix(path, "/recreate"):
+		vmID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/recreate"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.failVMs[vmID] {
+			http.Error(w, `{"message": "template unavailable"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		f.recreated = append(f.recreated, vmID)
+		f.installing[vmID] = true
+		f.maxInstall = max(f.maxInstall, len(f.installing))
+		f.vms[vmID].TemplateID = int(body["template_id"].(float64))
+		f.vms[vmID].State = "installing"
+		_ = json.NewEncoder(w).Encode(Action{ID: vmID, Name: "recreate", State: "sent"})
+
+	case r.Method == "PUT" && strings.HasSuffix(path, "/root-password"):
+		vmID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/root-password"))
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.passwords[vmID] = body["password"]
+		_ = json.NewEncoder(w).Encode(Action{ID: vmID, Name: "set_root_password", State: "success"})
+
+	case r.Method == "GET" && strings.Contains(path, "/actions/"):
+		_ = json.NewEncoder(w).Encode(Action{State: "success"})
+
+	case r.Method == "GET":
+		vmID, _ := strconv.Atoi(strings.TrimPrefix(path, "/"))
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		vm, ok := f.vms[vmID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(vm)
+		// The install finishes right after it is first observed
+		if f.installing[vmID] {
+			delete(f.installing, vmID)
+			vm.State = vpsStateRunning
+		}
+
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/billing/v1/subscriptions/"):
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.cancelled = append(f.cancelled, strings.TrimPrefix(r.URL.Path, "/api/billing/v1/subscriptions/"))
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func vpsGroupInstanceState(size, templateID int) *terraform.InstanceState {
+	attrs := map[string]string{
+		"size":             strconv.Itoa(size),
+		"hostname_pattern": "worker-%02d.example.com",
+		"plan":             "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id":   "9",
+		"template_id":      strconv.Itoa(templateID),
+		"parallelism":      "3",
+		"max_unavailable":  "1",
+		"on_destroy":       vpsOnDestroyCancel,
+		"instances.#":      strconv.Itoa(size),
+	}
+	var ids []string
+	for i := 0; i < size; i++ {
+		prefix := fmt.Sprintf("instances.%d.", i)
+		attrs[prefix+"index"] = strconv.Itoa(i + 1)
+		attrs[prefix+"vps_id"] = strconv.Itoa(11 + i)
+		attrs[prefix+"hostname"] = fmt.Sprintf("worker-%02d.example.com", i+1)
+		attrs[prefix+"template_id"] = strconv.Itoa(templateID)
+		attrs[prefix+"status"] = vpsStateRunning
+		ids = append(ids, strconv.Itoa(11+i))
+	}
+	attrs["id"] = strings.Join(ids, ",")
+	return &terraform.InstanceState{ID: attrs["id"], Attributes: attrs}
+}
+
+func vpsGroupConfig(overrides map[string]interface{}) *terraform.ResourceConfig {
+	cfg := map[string]interface{}{
+		"size":             3,
+		"hostname_pattern": "worker-%02d.example.com",
+		"plan":             "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id":   9,
+		"template_id":      1002,
+	}
+	for k, v := range overrides {
+		cfg[k] = v
+	}
+	return terraform.NewResourceConfigRaw(cfg)
+}
+
+func applyVPSGroup(t *testing.T, client *HostingerClient, state *terraform.InstanceState, cfg *terraform.ResourceConfig) (*terraform.InstanceState, error) {
+	t.Helper()
+	resource := resourceHostingerVPSGroup()
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	// No client for the diff, the plan and template checks are covered by the hostinger_vps tests
+	diff, err := resource.Diff(context.Background(), state, cfg, nil)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	newState, diags := resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		return newState, fmt.Errorf("%v", diags)
+	}
+	return newState, nil
+}
+
+func TestValidateVPSGroupHostnamePattern(t *testing.T) {
+	tests := map[string]bool{
+		"worker-%02d.example.com":  true,
+		"node%d.example.com":       true,
+		"worker.example.com":       false,
+		"worker-%d-%d.example.com": false,
+		"worker-%s.example.com":    false,
+		"worker-%02d":              false,
+	}
+	for pattern, valid := range tests {
+		_, errs := validateVPSGroupHostnamePattern(pattern, "hostname_pattern")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", pattern, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", pattern)
+		}
+	}
+}
+
+func TestResourceHostingerVPSGroup_Create(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	state, err := applyVPSGroup(t, client, nil, vpsGroupConfig(map[string]interface{}{"size": 5, "parallelism": 2}))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if api.maxBuying > 2 {
+		t.Errorf("expected at most 2 purchases in flight, got %d", api.maxBuying)
+	}
+	if state.Attributes["instances.#"] != "5" || state.Attributes["vps_ids.#"] != "5" {
+		t.Fatalf("expected 5 instances, got %v", state.Attributes)
+	}
+	for i := 0; i < 5; i++ {
+		want := fmt.Sprintf("worker-%02d.example.com", i+1)
+		if got := state.Attributes[fmt.Sprintf("instances.%d.hostname", i)]; got != want {
+			t.Errorf("instance %d: expected hostname %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestResourceHostingerVPSGroup_CreatePartialFailure(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	api.failHosts["worker-02.example.com"] = true
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	resource := resourceHostingerVPSGroup()
+	cfg := vpsGroupConfig(nil)
+	diff, err := resource.Diff(context.Background(), &terraform.InstanceState{}, cfg, nil)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	state, diags := resource.Apply(context.Background(), &terraform.InstanceState{}, diff, client)
+	if diags.HasError() {
+		t.Fatalf("expected the failed purchase not to fail the apply, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "worker-02.example.com") {
+		t.Fatalf("expected a warning for the failed purchase, got %v", diags)
+	}
+	if state == nil || state.ID == "" || state.Tainted {
+		t.Fatalf("expected the purchased instances to be kept in state, got %+v", state)
+	}
+	if state.Attributes["instances.#"] != "2" || state.Attributes["instances.1.index"] != "3" {
+		t.Errorf("expected instances 1 and 3 in state, got %v", state.Attributes)
+	}
+
+	// The next plan buys the missing index in place
+	delete(api.failHosts, "worker-02.example.com")
+	diff, err = resource.Diff(context.Background(), state, cfg, nil)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	if diff.RequiresNew() || diff.Attributes["size"] == nil || diff.Attributes["size"].New != "3" {
+		t.Fatalf("expected an in-place update of size, got %+v", diff.Attributes)
+	}
+	id := state.ID
+	state, diags = resource.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("apply failed: %v", diags)
+	}
+	if state.ID != id || state.Attributes["instances.#"] != "3" || state.Attributes["instances.1.hostname"] != "worker-02.example.com" {
+		t.Errorf("expected index 2 to be added to the same group, got %s %v", state.ID, state.Attributes)
+	}
+	if len(api.cancelled) != 0 {
+		t.Errorf("expected no instance to be released, got %v", api.cancelled)
+	}
+}
+
+func TestResourceHostingerVPSGroup_RollingTemplateChange(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	for i := 0; i < 5; i++ {
+		api.addVM(11+i, 1002, fmt.Sprintf("worker-%02d.example.com", i+1))
+	}
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	state, err := applyVPSGroup(t, client, vpsGroupInstanceState(5, 1002), vpsGroupConfig(map[string]interface{}{
+		"size":            5,
+		"template_id":     1077,
+		"max_unavailable": 2,
+	}))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if len(api.recreated) != 5 {
+		t.Fatalf("expected 5 reinstalls, got %v", api.recreated)
+	}
+	if api.maxInstall > 2 {
+		t.Errorf("expected at most 2 instances reinstalling at once, got %d", api.maxInstall)
+	}
+	// Batches follow the index order
+	for i, batch := range [][]int{{11, 12}, {13, 14}, {15}} {
+		start := i * 2
+		got := map[int]bool{}
+		for _, id := range api.recreated[start : start+len(batch)] {
+			got[id] = true
+		}
+		for _, id := range batch {
+			if !got[id] {
+				t.Errorf("expected VPS %d in batch %d, got %v", id, i, api.recreated)
+			}
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if got := state.Attributes[fmt.Sprintf("instances.%d.template_id", i)]; got != "1077" {
+			t.Errorf("instance %d: expected template 1077, got %s", i, got)
+		}
+	}
+}
+
+func TestResourceHostingerVPSGroup_Resize(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	for i := 0; i < 3; i++ {
+		api.addVM(11+i, 1002, fmt.Sprintf("worker-%02d.example.com", i+1))
+	}
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	state, err := applyVPSGroup(t, client, vpsGroupInstanceState(3, 1002), vpsGroupConfig(map[string]interface{}{"size": 2}))
+	if err != nil {
+		t.Fatalf("shrink failed: %v", err)
+	}
+	if len(api.cancelled) != 1 || api.cancelled[0] != "sub-13" {
+		t.Errorf("expected the subscription of index 3 to be cancelled, got %v", api.cancelled)
+	}
+	if state.ID != "11,12,13" || state.Attributes["vps_ids.#"] != "2" {
+		t.Errorf("expected the ID to be kept and 2 VPS IDs, got %s %v", state.ID, state.Attributes)
+	}
+
+	// Index 3 is free again and gets a new VPS
+	delete(api.vms, 13)
+	state, err = applyVPSGroup(t, client, state, vpsGroupConfig(map[string]interface{}{"size": 3}))
+	if err != nil {
+		t.Fatalf("grow failed: %v", err)
+	}
+	if state.Attributes["instances.2.index"] != "3" || state.Attributes["instances.2.vps_id"] != "101" {
+		t.Errorf("expected a new VPS at index 3, got %v", state.Attributes)
+	}
+	if len(api.recreated) != 0 {
+		t.Errorf("expected no reinstall, got %v", api.recreated)
+	}
+}
+
+func TestResourceHostingerVPSGroup_RollingTemplateChangeFailure(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	for i := 0; i < 3; i++ {
+		api.addVM(11+i, 1002, fmt.Sprintf("worker-%02d.example.com", i+1))
+	}
+	api.failVMs[12] = true
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	cfg := vpsGroupConfig(map[string]interface{}{"template_id": 1077})
+	state, err := applyVPSGroup(t, client, vpsGroupInstanceState(3, 1002), cfg)
+	if err == nil {
+		t.Fatal("expected the rollout to fail")
+	}
+	// The rollout stops at the failed batch and the next plan retries it
+	if len(api.recreated) != 1 || api.recreated[0] != 11 {
+		t.Errorf("expected only VPS 11 to be reinstalled, got %v", api.recreated)
+	}
+	if state.Attributes["template_id"] != "1002" || state.Attributes["instances.0.template_id"] != "1077" {
+		t.Errorf("expected the old template_id to be kept, got %v", state.Attributes)
+	}
+
+	delete(api.failVMs, 12)
+	if _, err := applyVPSGroup(t, client, state, cfg); err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if len(api.recreated) != 3 || api.recreated[1] != 12 || api.recreated[2] != 13 {
+		t.Errorf("expected the retry to skip VPS 11, got %v", api.recreated)
+	}
+}
+
+func TestResourceHostingerVPSGroup_PasswordRotation(t *testing.T) {
+	api := newFakeVPSGroupAPI(t)
+	for i := 0; i < 3; i++ {
+		api.addVM(11+i, 1002, fmt.Sprintf("worker-%02d.example.com", i+1))
+	}
+	mockServer := httptest.NewServer(api)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	state := vpsGroupInstanceState(3, 1002)
+	state.Attributes["password"] = "OldP4ssword1"
+	state, err := applyVPSGroup(t, client, state, vpsGroupConfig(map[string]interface{}{"password": "NewP4ssword1"}))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	for _, id := range []int{11, 12, 13} {
+		if api.passwords[id] != "NewP4ssword1" {
+			t.Errorf("expected the password of VPS %d to be rotated, got %q", id, api.passwords[id])
+		}
+	}
+	if len(api.recreated) != 0 {
+		t.Errorf("expected no reinstall, got %v", api.recreated)
+	}
+
+	// Instances being reinstalled get the password from the reinstall instead
+	api.passwords = map[int]string{}
+	if _, err := applyVPSGroup(t, client, state, vpsGroupConfig(map[string]interface{}{"password": "NextP4ssword1", "template_id": 1077})); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(api.passwords) != 0 || len(api.recreated) != 3 {
+		t.Errorf("expected only reinstalls, got passwords %v and reinstalls %v", api.passwords, api.recreated)
+	}
+}
+
+func TestResourceHostingerVPSGroup_RejectedChanges(t *testing.T) {
+	state := vpsGroupInstanceState(3, 1002)
+	state.Attributes["payment_method_id"] = "5"
+	state.Attributes["ssh_key_ids.#"] = "2"
+	state.Attributes["ssh_key_ids.0"] = "7"
+	state.Attributes["ssh_key_ids.1"] = "8"
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "unchanged",
+			config: map[string]interface{}{"payment_method_id": 5, "ssh_key_ids": []interface{}{7, 8}},
+		},
+		{
+			name:   "added and reordered keys",
+			config: map[string]interface{}{"payment_method_id": 5, "ssh_key_ids": []interface{}{9, 8, 7}},
+		},
+		{
+			name:    "removed key",
+			config:  map[string]interface{}{"payment_method_id": 5, "ssh_key_ids": []interface{}{8}},
+			wantErr: "SSH key 7 cannot be removed from ssh_key_ids",
+		},
+		{
+			name:    "payment method",
+			config:  map[string]interface{}{"payment_method_id": 6, "ssh_key_ids": []interface{}{7, 8}},
+			wantErr: "payment_method_id cannot be changed on an existing group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resourceHostingerVPSGroup().Diff(context.Background(), state, vpsGroupConfig(tt.config), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
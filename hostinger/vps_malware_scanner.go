@@ -0,0 +1,129 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MalwareScanMetrics holds the latest results of the Monarx malware scanner.
+type MalwareScanMetrics struct {
+	Records       int    `json:"records"`
+	Malicious     int    `json:"malicious"`
+	Compromised   int    `json:"compromised"`
+	ScannedFiles  int    `json:"scanned_files"`
+	ScanStartedAt string `json:"scan_started_at"`
+	ScanEndedAt   string `json:"scan_ended_at"`
+}
+
+func dataSourceHostingerVPSMalwareScan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostingerVPSMalwareScanRead,
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the VPS with the malware scanner installed.",
+			},
+			"records":           {Type: schema.TypeInt, Computed: true, Description: "Total number of findings."},
+			"malicious_files":   {Type: schema.TypeInt, Computed: true, Description: "Number of malicious files found."},
+			"compromised_files": {Type: schema.TypeInt, Computed: true, Description: "Number of compromised files found."},
+			"scanned_files":     {Type: schema.TypeInt, Computed: true, Description: "Number of files scanned."},
+			"scan_started_at":   {Type: schema.TypeString, Computed: true, Description: "Start time of the last scan."},
+			"scan_ended_at":     {Type: schema.TypeString, Computed: true, Description: "End time of the last scan."},
+		},
+	}
+}
+
+func dataSourceHostingerVPSMalwareScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	metrics, err := client.GetMalwareScanMetrics(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			return diag.Errorf("the malware scanner is not installed on VPS %d", vmID)
+		}
+		return diag.FromErr(fmt.Errorf("failed to read malware scan metrics: %w", err))
+	}
+
+	values := map[string]interface{}{
+		"records":           metrics.Records,
+		"malicious_files":   metrics.Malicious,
+		"compromised_files": metrics.Compromised,
+		"scanned_files":     metrics.ScannedFiles,
+		"scan_started_at":   metrics.ScanStartedAt,
+		"scan_ended_at":     metrics.ScanEndedAt,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set %s: %w", k, err))
+		}
+	}
+
+	d.SetId(strconv.Itoa(vmID))
+	return nil
+}
+
+// HostingerClient implementations:
+
+// GetMalwareScanMetrics returns the scanner results, or ErrNotFound when the
+// scanner is not installed on the VPS.
+func (c *HostingerClient) GetMalwareScanMetrics(vmID int) (*MalwareScanMetrics, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/monarx", c.BaseURL, vmID)
+	req, _ := http.NewRequest("GET", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("read malware scan metrics failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// A VPS without the scanner answers with an empty body or null
+	if len(body) == 0 || string(body) == "null" {
+		return nil, ErrNotFound
+	}
+
+	var metrics MalwareScanMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
+
+func (c *HostingerClient) InstallMalwareScanner(vmID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/monarx", c.BaseURL, vmID)
+	action, err := c.doVPSAction("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("install malware scanner failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) UninstallMalwareScanner(vmID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/monarx", c.BaseURL, vmID)
+	action, err := c.doVPSAction("DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("uninstall malware scanner failed: %w", err)
+	}
+	return action, nil
+}
@@ -0,0 +1,44 @@
+package hostinger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDataSourceHostingerVPSMalwareScan(t *testing.T) {
+	installed := true
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/vps/v1/virtual-machines/42/monarx" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if !installed {
+			_, _ = w.Write([]byte(`null`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records": 3, "malicious": 2, "compromised": 1, "scanned_files": 1200, "scan_started_at": "2026-10-18T01:00:00Z", "scan_ended_at": "2026-10-18T01:05:00Z"}`))
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := dataSourceHostingerVPSMalwareScan().TestResourceData()
+	_ = d.Set("vps_id", 42)
+
+	if diags := dataSourceHostingerVPSMalwareScanRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if d.Get("malicious_files") != 2 || d.Get("compromised_files") != 1 || d.Get("scan_ended_at") != "2026-10-18T01:05:00Z" {
+		t.Errorf("unexpected scan results: %v %v %v", d.Get("malicious_files"), d.Get("compromised_files"), d.Get("scan_ended_at"))
+	}
+
+	installed = false
+	if _, err := client.GetMalwareScanMetrics(42); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound when the scanner is not installed, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// MetricSeries is a single utilization series, keyed by unix timestamp.
+type MetricSeries struct {
+	Unit  string             `json:"unit"`
+	Usage map[string]float64 `json:"usage"`
+}
+
+// VPSMetrics holds the utilization series of a VPS over a time range.
+type VPSMetrics struct {
+	CPUUsage        *MetricSeries `json:"cpu_usage"`
+	RAMUsage        *MetricSeries `json:"ram_usage"`
+	DiskSpace       *MetricSeries `json:"disk_space"`
+	OutgoingTraffic *MetricSeries `json:"outgoing_traffic"`
+	IncomingTraffic *MetricSeries `json:"incoming_traffic"`
+	Uptime          *MetricSeries `json:"uptime"`
+}
+
+func metricSeriesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unit":    {Type: schema.TypeString, Computed: true, Description: "Unit of the values."},
+				"average": {Type: schema.TypeFloat, Computed: true, Description: "Average value over the range."},
+				"maximum": {Type: schema.TypeFloat, Computed: true, Description: "Highest value over the range."},
+				"latest":  {Type: schema.TypeFloat, Computed: true, Description: "Most recent value in the range."},
+				"points": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Data points, oldest first.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"timestamp": {Type: schema.TypeInt, Computed: true, Description: "Unix timestamp of the data point."},
+							"value":     {Type: schema.TypeFloat, Computed: true, Description: "Value of the data point."},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHostingerVPSMetrics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostingerVPSMetricsRead,
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the VPS.",
+			},
+			"date_from": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Start of the range, in RFC 3339 format.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"date_to": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "End of the range, in RFC 3339 format.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"cpu_usage":        metricSeriesSchema("CPU usage."),
+			"ram_usage":        metricSeriesSchema("RAM usage."),
+			"disk_space":       metricSeriesSchema("Used disk space."),
+			"outgoing_traffic": metricSeriesSchema("Outgoing network traffic."),
+			"incoming_traffic": metricSeriesSchema("Incoming network traffic."),
+			"uptime":           metricSeriesSchema("Uptime."),
+		},
+	}
+}
+
+func dataSourceHostingerVPSMetricsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	from, _ := time.Parse(time.RFC3339, d.Get("date_from").(string))
+	to, _ := time.Parse(time.RFC3339, d.Get("date_to").(string))
+	if !to.After(from) {
+		return diag.Errorf("date_to must be after date_from")
+	}
+
+	metrics, err := client.GetVPSMetrics(vmID, from, to)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read VPS metrics: %w", err))
+	}
+
+	series := map[string]*MetricSeries{
+		"cpu_usage":        metrics.CPUUsage,
+		"ram_usage":        metrics.RAMUsage,
+		"disk_space":       metrics.DiskSpace,
+		"outgoing_traffic": metrics.OutgoingTraffic,
+		"incoming_traffic": metrics.IncomingTraffic,
+		"uptime":           metrics.Uptime,
+	}
+	for k, s := range series {
+		if err := d.Set(k, flattenMetricSeries(s)); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set %s: %w", k, err))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d/%d", vmID, from.Unix(), to.Unix()))
+	return nil
+}
+
+func flattenMetricSeries(s *MetricSeries) []map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+
+	points := make([]map[string]interface{}, 0, len(s.Usage))
+	for ts, value := range s.Usage {
+		timestamp, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		points = append(points, map[string]interface{}{"timestamp": int(timestamp), "value": value})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i]["timestamp"].(int) < points[j]["timestamp"].(int)
+	})
+
+	var sum, maximum, latest float64
+	for i, p := range points {
+		v := p["value"].(float64)
+		sum += v
+		if i == 0 || v > maximum {
+			maximum = v
+		}
+		latest = v
+	}
+	average := 0.0
+	if len(points) > 0 {
+		average = sum / float64(len(points))
+	}
+
+	return []map[string]interface{}{{
+		"unit":    s.Unit,
+		"average": average,
+		"maximum": maximum,
+		"latest":  latest,
+		"points":  points,
+	}}
+}
+
+// HostingerClient implementations:
+
+func (c *HostingerClient) GetVPSMetrics(vmID int, from, to time.Time) (*VPSMetrics, error) {
+	query := url.Values{}
+	query.Set("date_from", from.UTC().Format(time.RFC3339))
+	query.Set("date_to", to.UTC().Format(time.RFC3339))
+
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/metrics?%s", c.BaseURL, vmID, query.Encode())
+	req, _ := http.NewRequest("GET", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("read VPS metrics failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var metrics VPSMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
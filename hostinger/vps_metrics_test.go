@@ -0,0 +1,65 @@
+package hostinger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDataSourceHostingerVPSMetrics(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/vps/v1/virtual-machines/42/metrics" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("date_from") != "2026-10-17T00:00:00Z" || r.URL.Query().Get("date_to") != "2026-10-18T00:00:00Z" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{
+			"cpu_usage": {"unit": "%", "usage": {"1792281600": 10, "1792285200": 30, "1792288800": 20}},
+			"ram_usage": {"unit": "bytes", "usage": {"1792281600": 1073741824}},
+			"uptime": {"unit": "milliseconds", "usage": {}}
+		}`))
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := dataSourceHostingerVPSMetrics().TestResourceData()
+	_ = d.Set("vps_id", 42)
+	_ = d.Set("date_from", "2026-10-17T00:00:00Z")
+	_ = d.Set("date_to", "2026-10-18T00:00:00Z")
+
+	if diags := dataSourceHostingerVPSMetricsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	if d.Get("cpu_usage.0.unit") != "%" || d.Get("cpu_usage.0.average") != 20.0 || d.Get("cpu_usage.0.maximum") != 30.0 {
+		t.Errorf("unexpected cpu aggregates: %v", d.Get("cpu_usage"))
+	}
+	// Points are ordered by timestamp, so latest is the last hour's value
+	if d.Get("cpu_usage.0.latest") != 20.0 || d.Get("cpu_usage.0.points.0.timestamp") != 1792281600 {
+		t.Errorf("unexpected cpu points: %v", d.Get("cpu_usage"))
+	}
+	if d.Get("ram_usage.0.latest") != 1073741824.0 {
+		t.Errorf("unexpected ram usage: %v", d.Get("ram_usage"))
+	}
+	if n := d.Get("disk_space.#"); n != 0 {
+		t.Errorf("expected no disk_space series, got %v", n)
+	}
+}
+
+func TestDataSourceHostingerVPSMetrics_InvalidRange(t *testing.T) {
+	d := dataSourceHostingerVPSMetrics().TestResourceData()
+	_ = d.Set("vps_id", 42)
+	_ = d.Set("date_from", "2026-10-18T00:00:00Z")
+	_ = d.Set("date_to", "2026-10-17T00:00:00Z")
+
+	if diags := dataSourceHostingerVPSMetricsRead(context.Background(), d, &HostingerClient{}); !diags.HasError() {
+		t.Fatal("expected an error for date_to before date_from")
+	}
+}
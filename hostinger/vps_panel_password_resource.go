@@ -0,0 +1,173 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// panelTemplateKeywords lists the control panels shipped by Hostinger VPS templates.
+// Templates are matched by name since the API exposes no dedicated flag.
+var panelTemplateKeywords = []string{
+	"cyberpanel",
+	"cloudpanel",
+	"cpanel",
+	"plesk",
+	"webmin",
+	"virtualmin",
+	"hestiacp",
+	"fastpanel",
+	"aapanel",
+	"coolify",
+	"dokploy",
+	"easypanel",
+}
+
+func resourceHostingerVPSPanelPassword() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSPanelPasswordCreate,
+		ReadContext:   resourceHostingerVPSPanelPasswordRead,
+		UpdateContext: resourceHostingerVPSPanelPasswordUpdate,
+		DeleteContext: resourceHostingerVPSPanelPasswordDelete,
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS whose control panel password is managed.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Control panel password. Changing it rotates the password in place.",
+				ValidateFunc: validateVPSPassword,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				Description:  "Write-only control panel password. It is never stored in state; bump `password_wo_version` to rotate it.",
+				ValidateFunc: validateVPSPassword,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Version of `password_wo`. Changing this value applies the current `password_wo` to the panel.",
+				RequiredWith: []string{"password_wo"},
+			},
+			"template_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the panel template installed on the VPS.",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSPanelPasswordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS %d: %w", vmID, err))
+	}
+
+	templateName := vm.TemplateName()
+	if !templateHasPanel(templateName) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "VPS template has no control panel",
+			Detail:   fmt.Sprintf("VPS %d runs template %q, which does not ship a control panel. A panel password can only be set on templates such as CyberPanel or CloudPanel.", vmID, templateName),
+		}}
+	}
+
+	if diags := setVPSPanelPassword(d, client, vmID); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(vmID))
+	return resourceHostingerVPSPanelPasswordRead(ctx, d, m)
+}
+
+func resourceHostingerVPSPanelPasswordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	if err := d.Set("vps_id", vm.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	if err := d.Set("template_name", vm.TemplateName()); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set template_name: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSPanelPasswordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, _ := strconv.Atoi(d.Id())
+
+	if d.HasChanges("password", "password_wo_version") {
+		if diags := setVPSPanelPassword(d, client, vmID); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceHostingerVPSPanelPasswordRead(ctx, d, m)
+}
+
+func resourceHostingerVPSPanelPasswordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The API cannot unset a panel password, so the current one is left in place.
+	d.SetId("")
+	return nil
+}
+
+func setVPSPanelPassword(d *schema.ResourceData, client *HostingerClient, vmID int) diag.Diagnostics {
+	password, diags := configuredPassword(d, "password", "password_wo")
+	if diags.HasError() {
+		return diags
+	}
+	if password == nil {
+		return diag.Errorf("one of `password` or `password_wo` must be set")
+	}
+
+	if err := client.SetPanelPassword(vmID, *password); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set panel password: %w", err))
+	}
+	return nil
+}
+
+// templateHasPanel reports whether a VPS template name refers to a template
+// that ships a control panel.
+func templateHasPanel(templateName string) bool {
+	name := strings.ToLower(templateName)
+	for _, kw := range panelTemplateKeywords {
+		if strings.Contains(name, kw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceHostingerVPSPanelPassword_Schema(t *testing.T) {
+	resource := resourceHostingerVPSPanelPassword()
+
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+}
+
+func TestResourceHostingerVPSPanelPassword_ExactlyOnePassword(t *testing.T) {
+	resource := resourceHostingerVPSPanelPassword()
+
+	cases := map[string]map[string]interface{}{
+		"neither": {"vps_id": 42},
+		"both":    {"vps_id": 42, "password": "Sup3r-Secret!", "password_wo": "Sup3r-Secret!"},
+	}
+	for name, cfg := range cases {
+		if diags := resource.Validate(terraform.NewResourceConfigRaw(cfg)); !diags.HasError() {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	if diags := resource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"vps_id": 42, "password": "Sup3r-Secret!"})); diags.HasError() {
+		t.Errorf("unexpected validation error: %v", diags)
+	}
+}
+
+func newPanelPasswordTestServer(t *testing.T, templateName string, passwords *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_ = json.NewEncoder(w).Encode(VirtualMachine{
+				ID:       42,
+				Template: map[string]interface{}{"id": float64(1121), "name": templateName},
+			})
+		case r.Method == "PUT" && r.URL.Path == "/api/vps/v1/virtual-machines/42/panel-password":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode panel password request: %v", err)
+			}
+			*passwords = append(*passwords, body["password"])
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestResourceHostingerVPSPanelPassword_Create(t *testing.T) {
+	var passwords []string
+	mockServer := newPanelPasswordTestServer(t, "Ubuntu 22.04 with CyberPanel", &passwords)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPSPanelPassword().Schema, map[string]interface{}{
+		"vps_id":   42,
+		"password": "Sup3r-Secret!",
+	})
+	if diags := resourceHostingerVPSPanelPasswordCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(passwords) != 1 || passwords[0] != "Sup3r-Secret!" {
+		t.Errorf("expected the panel password to be sent once, got %v", passwords)
+	}
+	if d.Id() != "42" || d.Get("template_name") != "Ubuntu 22.04 with CyberPanel" {
+		t.Errorf("unexpected state: id %q, template_name %q", d.Id(), d.Get("template_name"))
+	}
+}
+
+func TestResourceHostingerVPSPanelPassword_CreateWithoutPanel(t *testing.T) {
+	var passwords []string
+	mockServer := newPanelPasswordTestServer(t, "Ubuntu 24.04", &passwords)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPSPanelPassword().Schema, map[string]interface{}{
+		"vps_id":   42,
+		"password": "Sup3r-Secret!",
+	})
+	diags := resourceHostingerVPSPanelPasswordCreate(context.Background(), d, client)
+	if !diags.HasError() || diags[0].Summary != "VPS template has no control panel" {
+		t.Fatalf("expected the missing panel diagnostic, got %v", diags)
+	}
+
+	if len(passwords) != 0 {
+		t.Errorf("expected no panel password request, got %v", passwords)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no ID to be set, got %q", d.Id())
+	}
+}
+
+func TestTemplateHasPanel(t *testing.T) {
+	cases := map[string]bool{
+		"Ubuntu 22.04 with CyberPanel": true,
+		"Debian 12 with CloudPanel":    true,
+		"AlmaLinux 8 with cPanel":      true,
+		"Ubuntu 24.04":                 false,
+		"":                             false,
+	}
+
+	for name, want := range cases {
+		if got := templateHasPanel(name); got != want {
+			t.Errorf("templateHasPanel(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestVirtualMachineTemplateName(t *testing.T) {
+	vm := VirtualMachine{Template: map[string]interface{}{"id": float64(1121), "name": "Ubuntu 22.04 with CyberPanel"}}
+	if got := vm.TemplateName(); got != "Ubuntu 22.04 with CyberPanel" {
+		t.Errorf("unexpected template name from object: %q", got)
+	}
+
+	vm = VirtualMachine{Template: "Debian 11"}
+	if got := vm.TemplateName(); got != "Debian 11" {
+		t.Errorf("unexpected template name from string: %q", got)
+	}
+}
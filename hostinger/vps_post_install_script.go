@@ -3,6 +3,8 @@ package hostinger
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +28,14 @@ func resourceHostingerVPSPostInstallScript() *schema.Resource {
 		ReadContext:   resourceHostingerVPSPostInstallScriptRead,
 		UpdateContext: resourceHostingerVPSPostInstallScriptUpdate,
 		DeleteContext: resourceHostingerVPSPostInstallScriptDelete,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceHostingerVPSPostInstallScriptV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: numericIDStateUpgradeV0("hostinger_vps_post_install_script"),
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -40,10 +50,32 @@ func resourceHostingerVPSPostInstallScript() *schema.Resource {
 					return strings.TrimSpace(old) == strings.TrimSpace(new)
 				},
 			},
+			"content_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the script content, ignoring surrounding whitespace. Pass it to `post_install_script_hash` on `hostinger_vps` to reinstall in the same apply that edits the script.",
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if !d.NewValueKnown("content") {
+				return d.SetNewComputed("content_sha256")
+			}
+			hash := postInstallScriptHash(d.Get("content").(string))
+			if old, _ := d.GetChange("content_sha256"); old.(string) != hash {
+				return d.SetNew("content_sha256", hash)
+			}
+			return nil
 		},
 	}
 }
 
+// postInstallScriptHash returns the hex SHA-256 of a script, ignoring the
+// surrounding whitespace the API may add or strip.
+func postInstallScriptHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
+}
+
 func resourceHostingerVPSPostInstallScriptCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*HostingerClient)
 	name := d.Get("name").(string)
@@ -59,7 +91,12 @@ func resourceHostingerVPSPostInstallScriptCreate(ctx context.Context, d *schema.
 
 func resourceHostingerVPSPostInstallScriptRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*HostingerClient)
-	id, _ := strconv.Atoi(d.Id())
+	id, err := strconv.Atoi(d.Id())
+	if err != nil || id <= 0 {
+		// If ID is not valid, remove from state
+		d.SetId("")
+		return nil
+	}
 
 	script, err := client.GetPostInstallScript(id)
 	if err != nil {
@@ -72,6 +109,9 @@ func resourceHostingerVPSPostInstallScriptRead(ctx context.Context, d *schema.Re
 	if err := d.Set("content", script.Content); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set content: %w", err))
 	}
+	if err := d.Set("content_sha256", postInstallScriptHash(script.Content)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set content_sha256: %w", err))
+	}
 	return nil
 }
 
@@ -0,0 +1,190 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceHostingerVPSPTRRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSPTRRecordCreate,
+		ReadContext:   resourceHostingerVPSPTRRecordRead,
+		UpdateContext: resourceHostingerVPSPTRRecordUpdate,
+		DeleteContext: resourceHostingerVPSPTRRecordDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceHostingerVPSPTRRecordImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS that owns the IP address.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "IPv4 or IPv6 address of the VPS to set the PTR record for.",
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Domain name the IP address resolves back to (e.g., `mail.example.com`).",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}$`), "must be a valid FQDN"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeDNSName(old) == normalizeDNSName(new)
+				},
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSPTRRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+	address := d.Get("ip_address").(string)
+
+	ip, diags := lookupVPSIPAddress(client, vmID, address)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := client.CreatePTRRecord(vmID, ip.ID, d.Get("domain").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create PTR record: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", vmID, address))
+	return resourceHostingerVPSPTRRecordRead(ctx, d, m)
+}
+
+func resourceHostingerVPSPTRRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+
+	vmID, address, err := parseVPSPTRRecordID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	ip, ok := vm.FindIPAddress(address)
+	if !ok || ip.PTR == "" {
+		// The IP was released or its PTR record removed outside Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("vps_id", vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	if err := d.Set("ip_address", ip.Address); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set ip_address: %w", err))
+	}
+	if err := d.Set("domain", ip.PTR); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set domain: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSPTRRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	if d.HasChange("domain") {
+		ip, diags := lookupVPSIPAddress(client, vmID, d.Get("ip_address").(string))
+		if diags.HasError() {
+			return diags
+		}
+
+		if err := client.CreatePTRRecord(vmID, ip.ID, d.Get("domain").(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update PTR record: %w", err))
+		}
+	}
+
+	return resourceHostingerVPSPTRRecordRead(ctx, d, m)
+}
+
+func resourceHostingerVPSPTRRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	ip, ok := vm.FindIPAddress(d.Get("ip_address").(string))
+	if ok {
+		if err := client.DeletePTRRecord(vmID, ip.ID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to delete PTR record: %w", err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHostingerVPSPTRRecordImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	vmID, address, err := parseVPSPTRRecordID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", vmID, address))
+	if err := d.Set("vps_id", vmID); err != nil {
+		return nil, fmt.Errorf("failed to set vps_id: %w", err)
+	}
+	if err := d.Set("ip_address", address); err != nil {
+		return nil, fmt.Errorf("failed to set ip_address: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseVPSPTRRecordID splits a `<vm_id>/<ip>` resource ID.
+func parseVPSPTRRecordID(id string) (int, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("unexpected ID format %q, expected <vm_id>/<ip>", id)
+	}
+
+	vmID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid VPS ID in %q: %w", id, err)
+	}
+	return vmID, parts[1], nil
+}
+
+func lookupVPSIPAddress(client *HostingerClient, vmID int, address string) (*IPAddress, diag.Diagnostics) {
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		return nil, diag.FromErr(fmt.Errorf("failed to fetch VPS %d: %w", vmID, err))
+	}
+
+	ip, ok := vm.FindIPAddress(address)
+	if !ok {
+		return nil, diag.Errorf("IP address %s is not assigned to VPS %d", address, vmID)
+	}
+	return ip, nil
+}
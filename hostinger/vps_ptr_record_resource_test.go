@@ -0,0 +1,77 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseVPSPTRRecordID(t *testing.T) {
+	vmID, address, err := parseVPSPTRRecordID("42/2a02:4780::1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if vmID != 42 || address != "2a02:4780::1" {
+		t.Errorf("unexpected result: %d %s", vmID, address)
+	}
+
+	for _, id := range []string{"42", "abc/1.2.3.4", "42/"} {
+		if _, _, err := parseVPSPTRRecordID(id); err == nil {
+			t.Errorf("expected error for ID %q", id)
+		}
+	}
+}
+
+func TestResourceHostingerVPSPTRRecord_Lifecycle(t *testing.T) {
+	ptr := ""
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":   42,
+				"ipv4": []map[string]interface{}{{"id": 7, "address": "192.0.2.10", "ptr": ptr}},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/ptr/7":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ptr = body["domain"]
+		case r.Method == "DELETE" && r.URL.Path == "/api/vps/v1/virtual-machines/42/ptr/7":
+			ptr = ""
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPSPTRRecord()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	_ = d.Set("vps_id", 42)
+	_ = d.Set("ip_address", "192.0.2.10")
+	_ = d.Set("domain", "mail.example.com")
+
+	if diags := resourceHostingerVPSPTRRecordCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if d.Id() != "42/192.0.2.10" || ptr != "mail.example.com" {
+		t.Errorf("unexpected state after create: id=%q ptr=%q", d.Id(), ptr)
+	}
+
+	if diags := resourceHostingerVPSPTRRecordDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if ptr != "" || d.Id() != "" {
+		t.Errorf("expected PTR record to be removed, got ptr=%q id=%q", ptr, d.Id())
+	}
+}
@@ -0,0 +1,132 @@
+package hostinger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	vpsStateInitial          = "initial"
+	vpsStateRunning          = "running"
+	vpsStateRecovery         = "recovery"
+	vpsStateStoppingRecovery = "stopping_recovery"
+)
+
+func resourceHostingerVPSRecoveryMode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSRecoveryModeCreate,
+		ReadContext:   resourceHostingerVPSRecoveryModeRead,
+		DeleteContext: resourceHostingerVPSRecoveryModeDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS to boot into recovery mode.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"root_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ForceNew:     true,
+				Description:  "Root password for the recovery environment.",
+				ValidateFunc: validateVPSPassword,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current state of the VPS (`recovery` while recovery mode is active).",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSRecoveryModeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+	rootPassword := d.Get("root_password").(string)
+
+	if err := client.StartRecoveryMode(vmID, rootPassword); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to start recovery mode: %w", err))
+	}
+
+	d.SetId(strconv.Itoa(vmID))
+
+	if _, err := waitForVPSState(ctx, client, vmID, d.Timeout(schema.TimeoutCreate), vpsStateRecovery); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for VPS %d to enter recovery mode: %w", vmID, err))
+	}
+
+	return resourceHostingerVPSRecoveryModeRead(ctx, d, m)
+}
+
+func resourceHostingerVPSRecoveryModeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	// Recovery mode was left outside Terraform
+	if vm.State != vpsStateRecovery {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("vps_id", vm.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	if err := d.Set("status", vm.State); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set status: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSRecoveryModeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, _ := strconv.Atoi(d.Id())
+
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+
+	if vm.State == vpsStateRecovery {
+		if err := client.StopRecoveryMode(vmID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to stop recovery mode: %w", err))
+		}
+	}
+
+	if vm.State == vpsStateRecovery || vm.State == vpsStateStoppingRecovery {
+		if _, err := waitForVPSState(ctx, client, vmID, d.Timeout(schema.TimeoutDelete), vpsStateRunning); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for VPS %d to leave recovery mode: %w", vmID, err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,69 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResourceHostingerVPSRecoveryMode_Lifecycle(t *testing.T) {
+	state := "running"
+	var rootPassword string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "state": state})
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/recovery":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			rootPassword = body["root_password"]
+			state = "recovery"
+		case r.Method == "DELETE" && r.URL.Path == "/api/vps/v1/virtual-machines/42/recovery":
+			state = "running"
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPSRecoveryMode()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	if err := d.Set("vps_id", 42); err != nil {
+		t.Fatalf("failed to set vps_id: %v", err)
+	}
+	if err := d.Set("root_password", "Recover1234"); err != nil {
+		t.Fatalf("failed to set root_password: %v", err)
+	}
+
+	if diags := resourceHostingerVPSRecoveryModeCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if rootPassword != "Recover1234" {
+		t.Errorf("expected root password to be sent, got %q", rootPassword)
+	}
+	if d.Id() != "42" || d.Get("status") != "recovery" {
+		t.Errorf("unexpected state after create: id=%q status=%v", d.Id(), d.Get("status"))
+	}
+
+	if diags := resourceHostingerVPSRecoveryModeDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if state != "running" {
+		t.Errorf("expected VPS to be running after delete, got %s", state)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected resource to be removed from state")
+	}
+}
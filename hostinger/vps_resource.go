@@ -3,20 +3,50 @@ package hostinger
 import (
 	"context"
 	"fmt"
+	"log"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	vpsOnDestroyCancel           = "cancel"
+	vpsOnDestroyDisableAutoRenew = "disable_auto_renew"
+	vpsOnDestroyForget           = "forget"
+)
+
 func resourceHostingerVPS() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceHostingerVPSCreate,
 		ReadContext:   resourceHostingerVPSRead,
 		DeleteContext: resourceHostingerVPSDelete,
 		UpdateContext: resourceHostingerVPSUpdate,
+		CustomizeDiff: customdiff.All(
+			resourceHostingerVPSCustomizeDiff,
+			resourceHostingerVPSScriptCustomizeDiff,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceHostingerVPSV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceHostingerVPSStateUpgradeV0,
+			},
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceHostingerVPSImport,
 		},
@@ -43,12 +73,27 @@ func resourceHostingerVPS() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"password": {
-				Type:         schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Root password for the VPS. Changing it rotates the password in place.",
+				ValidateFunc:  validateVPSPassword,
+				ConflictsWith: []string{"password_wo"},
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				Description:   "Write-only root password for the VPS. It is never stored in state; bump `password_wo_version` to rotate it.",
+				ValidateFunc:  validateVPSPassword,
+				ConflictsWith: []string{"password"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
 				Optional:     true,
-				Sensitive:    true,
-				ForceNew:     true,
-				Description:  "Root password for the new VPS (will be sent to the server).",
-				ValidateFunc: validation.StringLenBetween(8, 100),
+				Description:  "Version of `password_wo`. Changing this value applies the current `password_wo` to the VPS.",
+				RequiredWith: []string{"password_wo"},
 			},
 			"hostname": {
 				Type:         schema.TypeString,
@@ -68,12 +113,53 @@ func resourceHostingerVPS() *schema.Resource {
 				Optional:    true,
 				Description: "ID of the post-install script to run after OS setup.",
 			},
+			"reinstall_on_script_change": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				Description:      "When true, reinstall the VPS with its current template whenever `post_install_script_id` or the content of the script changes. Reinstalling wipes the disk.",
+				ValidateDiagFunc: warnVPSReinstallOnScriptChange,
+			},
+			"post_install_script_hash": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "SHA-256 of the post-install script content the VPS was installed with. Set it to `content_sha256` of the script resource to reinstall in the same apply that edits the script; otherwise content edits are detected from the API on the next plan.",
+			},
 			"ssh_key_ids": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 				Description: "List of SSH key IDs to attach to the VPS after setup.",
 			},
+			"nameservers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MinItems:    1,
+				MaxItems:    2,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsIPAddress},
+				Description: "Resolver IP addresses (`ns1`, `ns2`) used by the VPS. If not set, the Hostinger defaults are kept. Removing it keeps the current resolvers.",
+			},
+			"malware_scanner_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the Monarx malware scanner is installed on the VPS. If not set, the scanner is left as is.",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, destroying the VPS fails with an error. Must be set to false and applied before the VPS can be destroyed.",
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      vpsOnDestroyCancel,
+				Description:  "Action taken when the resource is destroyed: `cancel` cancels the subscription immediately, `disable_auto_renew` lets it expire at the end of the billing period, `forget` only removes the VPS from state.",
+				ValidateFunc: validation.StringInSlice([]string{vpsOnDestroyCancel, vpsOnDestroyDisableAutoRenew, vpsOnDestroyForget}, false),
+			},
 			// Output attributes:
 			"ipv4_address": {
 				Type:        schema.TypeString,
@@ -86,15 +172,65 @@ func resourceHostingerVPS() *schema.Resource {
 				Description: "Public IPv6 address assigned to the VPS (if available).",
 			},
 			"vps_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "The Hostinger VPS instance ID.",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The Hostinger VPS instance ID. Set it to set up an existing VPS in the `initial` state instead of purchasing a new one.",
+				ConflictsWith: []string{"adopt_initial"},
+			},
+			"adopt_initial": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "When true, set up an existing VPS in the `initial` state matching `plan` and `data_center_id` instead of purchasing a new one.",
+				ConflictsWith: []string{"vps_id"},
 			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Current status of the VPS (e.g., running, stopped, installing, reinstalling).",
 			},
+			"cpus": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of vCPUs.",
+			},
+			"memory_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Memory in megabytes.",
+			},
+			"disk_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Disk size in gigabytes.",
+			},
+			"bandwidth": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Monthly bandwidth in megabytes.",
+			},
+			"os_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the installed operating system.",
+			},
+			"subscription_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the billing subscription of the VPS.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the VPS was created.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the subscription of the VPS expires.",
+			},
 		},
 	}
 }
@@ -107,10 +243,17 @@ func resourceHostingerVPSCreate(ctx context.Context, d *schema.ResourceData, m i
 	dataCenterID := d.Get("data_center_id").(int)
 	templateID := d.Get("template_id").(int)
 
-	var passwordPtr *string
-	if v, ok := d.GetOk("password"); ok {
-		pw := v.(string)
-		passwordPtr = &pw
+	// The plan only checks values known at plan time
+	if err := validateVPSPlan(client, plan); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateVPSTemplateAndDataCenter(client, templateID, dataCenterID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	passwordPtr, diags := vpsRootPassword(d)
+	if diags.HasError() {
+		return diags
 	}
 	var hostnamePtr *string
 	if v, ok := d.GetOk("hostname"); ok {
@@ -129,51 +272,49 @@ func resourceHostingerVPSCreate(ctx context.Context, d *schema.ResourceData, m i
 		paymentMethodIDPtr = &id
 	}
 
-	// Validate inputs
-	ok, err := client.ValidatePlanID(plan)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to validate plan: %w", err))
-	}
-	if !ok {
-		return diag.Errorf("Invalid plan ID: %s", plan)
+	setup := PurchaseVPSSetup{
+		DataCenterID:        dataCenterID,
+		TemplateID:          templateID,
+		Password:            passwordPtr,
+		Hostname:            hostnamePtr,
+		PostInstallScriptID: postInstallScriptIDPtr,
 	}
 
-	ok, err = client.ValidateTemplateID(templateID)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to validate template_id: %w", err))
-	}
-	if !ok {
-		return diag.Errorf("Invalid template ID: %d", templateID)
+	var err error
+	vmID := d.Get("vps_id").(int)
+	adopt := d.Get("adopt_initial").(bool)
+	if adopt {
+		vmID, err = client.claimInitialVirtualMachine(func(claimed map[int]bool) (int, error) {
+			return findInitialVirtualMachine(client, plan, dataCenterID, claimed)
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	ok, err = client.ValidateDataCenterID(dataCenterID)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to validate data_center_id: %w", err))
-	}
-	if !ok {
-		return diag.Errorf("Invalid data center ID: %d", dataCenterID)
-	}
-
-	// Purchase and setup VPS in a single API call
-	purchaseReq := PurchaseVPSRequest{
-		ItemID:          plan,
-		PaymentMethodID: paymentMethodIDPtr,
-		Setup: PurchaseVPSSetup{
-			DataCenterID:        dataCenterID,
-			TemplateID:          templateID,
-			Password:            passwordPtr,
-			Hostname:            hostnamePtr,
-			PostInstallScriptID: postInstallScriptIDPtr,
-		},
-	}
+	if vmID != 0 {
+		// Set up a subscription bought beforehand instead of purchasing one
+		if diags := setupInitialVirtualMachine(client, vmID, plan, setup); diags.HasError() {
+			if adopt {
+				client.releaseInitialVirtualMachine(vmID)
+			}
+			return diags
+		}
+	} else {
+		// Purchase and setup VPS in a single API call
+		purchaseReq := PurchaseVPSRequest{
+			ItemID:          plan,
+			PaymentMethodID: paymentMethodIDPtr,
+			Setup:           setup,
+		}
 
-	purchaseRes, err := client.PurchaseVPS(purchaseReq)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to purchase VPS: %w", err))
+		purchaseRes, err := client.PurchaseVPS(purchaseReq)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to purchase VPS: %w", err))
+		}
+		vmID = purchaseRes.VirtualMachine.ID
 	}
 
-	vmID := purchaseRes.VirtualMachine.ID
-
 	// Attach SSH keys (optional)
 	if v, ok := d.GetOk("ssh_key_ids"); ok {
 		rawIDs := v.([]interface{})
@@ -193,6 +334,36 @@ func resourceHostingerVPSCreate(ctx context.Context, d *schema.ResourceData, m i
 	if err := d.Set("vps_id", vmID); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
 	}
+	if postInstallScriptIDPtr != nil && d.Get("reinstall_on_script_change").(bool) && d.Get("post_install_script_hash").(string) == "" {
+		// Without a recorded hash the next plan records one without reinstalling
+		if script, err := client.GetPostInstallScript(*postInstallScriptIDPtr); err == nil {
+			if err := d.Set("post_install_script_hash", postInstallScriptHash(script.Content)); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to set post_install_script_hash: %w", err))
+			}
+		} else {
+			log.Printf("[WARN] Failed to read post-install script %d of VPS %d: %s", *postInstallScriptIDPtr, vmID, err)
+		}
+	}
+	nameservers, setNameservers := d.GetOk("nameservers")
+	scanner := d.Get("malware_scanner_enabled").(bool)
+	if setNameservers || scanner {
+		// Nameservers and the scanner can only be set once the OS is up
+		if _, err := waitForVPSState(ctx, client, vmID, d.Timeout(schema.TimeoutCreate), vpsStateRunning); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for VPS %d to run: %w", vmID, err))
+		}
+	}
+	if setNameservers {
+		ns1, ns2 := expandVPSNameservers(nameservers.([]interface{}))
+		if err := client.SetNameservers(vmID, ns1, ns2); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set nameservers: %w", err))
+		}
+	}
+	if scanner {
+		if err := setVPSMalwareScanner(ctx, client, vmID, true, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if hostnamePtr != nil {
 		if err := d.Set("hostname", *hostnamePtr); err != nil {
 			return diag.FromErr(fmt.Errorf("failed to set hostname: %w", err))
@@ -232,6 +403,44 @@ func resourceHostingerVPSRead(ctx context.Context, d *schema.ResourceData, m int
 	if err := d.Set("status", vm.State); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set status: %w", err))
 	}
+	if err := d.Set("nameservers", flattenVPSNameservers(vm)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set nameservers: %w", err))
+	}
+
+	expiresAt := ""
+	if vm.SubscriptionID != "" {
+		// Billing details are informational, a failure here must not break refresh
+		if sub, err := client.GetSubscriptionDetails(vm.SubscriptionID); err == nil {
+			expiresAt = sub.ExpiresAt
+		}
+	}
+	computed := map[string]interface{}{
+		"cpus":            vm.CPUCount(),
+		"memory_mb":       vm.MemoryMB(),
+		"disk_gb":         vm.DiskGB(),
+		"bandwidth":       vm.Bandwidth,
+		"os_name":         vm.OperatingSystem(),
+		"subscription_id": vm.SubscriptionID,
+		"created_at":      vm.CreatedAt,
+		"expires_at":      expiresAt,
+	}
+	for k, v := range computed {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set %s: %w", k, err))
+		}
+	}
+
+	// The scanner is only looked up for VPSs that manage it, so the others do
+	// not pay for an extra request on every refresh
+	if _, ok := d.GetOkExists("malware_scanner_enabled"); ok { //nolint:staticcheck // false is a set value
+		_, err = client.GetMalwareScanMetrics(vmID)
+		if err != nil && err != ErrNotFound {
+			return diag.FromErr(fmt.Errorf("failed to check malware scanner: %w", err))
+		}
+		if err := d.Set("malware_scanner_enabled", err == nil); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set malware_scanner_enabled: %w", err))
+		}
+	}
 	if len(vm.IPv4) > 0 {
 		if err := d.Set("ipv4_address", vm.IPv4[0].Address); err != nil {
 			return diag.FromErr(fmt.Errorf("failed to set ipv4_address: %w", err))
@@ -250,7 +459,7 @@ func resourceHostingerVPSRead(ctx context.Context, d *schema.ResourceData, m int
 			return diag.FromErr(fmt.Errorf("failed to clear ipv6_address: %w", err))
 		}
 	}
-	return nil
+	return warnVPSScriptLookup(client, d)
 }
 
 func resourceHostingerVPSDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -258,18 +467,40 @@ func resourceHostingerVPSDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	vmID := d.Get("vps_id").(int)
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot destroy VPS %d: deletion_protection is enabled. Set deletion_protection = false and apply before destroying it", vmID)
+	}
+
+	if err := releaseVPS(client, vmID, d.Get("on_destroy").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// releaseVPS gives up the subscription of a VPS as requested by on_destroy.
+func releaseVPS(client *HostingerClient, vmID int, onDestroy string) error {
+	if onDestroy == vpsOnDestroyForget {
+		return nil
+	}
+
 	// Always resolve subscription ID from the API
 	subscriptionID, err := client.GetSubscriptionIDByVMID(vmID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to find subscription for VPS %d: %w", vmID, err))
+		return fmt.Errorf("failed to find subscription for VPS %d: %w", vmID, err)
 	}
 
-	err = client.CancelSubscription(subscriptionID)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to cancel subscription: %w", err))
+	switch onDestroy {
+	case vpsOnDestroyDisableAutoRenew:
+		if err := client.DisableAutoRenewal(subscriptionID); err != nil {
+			return fmt.Errorf("failed to disable auto-renewal: %w", err)
+		}
+	default:
+		if err := client.CancelSubscription(subscriptionID); err != nil {
+			return fmt.Errorf("failed to cancel subscription: %w", err)
+		}
 	}
-
-	d.SetId("")
 	return nil
 }
 
@@ -286,14 +517,13 @@ func resourceHostingerVPSUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	if d.HasChange("template_id") {
+	if d.HasChange("template_id") || vpsScriptReinstallPlanned(d) {
 		vmID := d.Get("vps_id").(int)
 		templateID := d.Get("template_id").(int)
 
-		var password *string
-		if v, ok := d.GetOk("password"); ok {
-			pw := v.(string)
-			password = &pw
+		password, diags := vpsRootPassword(d)
+		if diags.HasError() {
+			return diags
 		}
 
 		var postScriptID *int
@@ -302,10 +532,37 @@ func resourceHostingerVPSUpdate(ctx context.Context, d *schema.ResourceData, m i
 			postScriptID = &id
 		}
 
-		err := client.RecreateVirtualMachine(vmID, templateID, password, postScriptID)
+		_, err := client.RecreateVirtualMachine(vmID, templateID, password, postScriptID)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to recreate VPS: %w", err))
 		}
+	} else if d.HasChanges("password", "password_wo_version") {
+		// A reinstall already applies the password, so only rotate it on its own
+		password, diags := vpsRootPassword(d)
+		if diags.HasError() {
+			return diags
+		}
+		if password != nil {
+			if err := client.SetRootPassword(vmID, *password); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update root password: %w", err))
+			}
+		}
+	}
+
+	if d.HasChange("nameservers") {
+		if v, ok := d.GetOk("nameservers"); ok {
+			ns1, ns2 := expandVPSNameservers(v.([]interface{}))
+			if err := client.SetNameservers(vmID, ns1, ns2); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update nameservers: %w", err))
+			}
+		}
+	}
+
+	if d.HasChange("malware_scanner_enabled") {
+		enabled := d.Get("malware_scanner_enabled").(bool)
+		if err := setVPSMalwareScanner(ctx, client, vmID, enabled, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if d.HasChange("ssh_key_ids") {
@@ -377,6 +634,13 @@ func resourceHostingerVPSImport(ctx context.Context, d *schema.ResourceData, m i
 		return nil, fmt.Errorf("failed to set status: %w", err)
 	}
 	
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, fmt.Errorf("failed to set deletion_protection: %w", err)
+	}
+	if err := d.Set("on_destroy", vpsOnDestroyCancel); err != nil {
+		return nil, fmt.Errorf("failed to set on_destroy: %w", err)
+	}
+
 	// Set the plan if we successfully retrieved it
 	if vm.Plan != "" {
 		if err := d.Set("plan", vm.Plan); err != nil {
@@ -410,3 +674,424 @@ func resourceHostingerVPSImport(ctx context.Context, d *schema.ResourceData, m i
 	
 	return []*schema.ResourceData{d}, nil
 }
+
+func expandVPSNameservers(raw []interface{}) (string, string) {
+	var ns1, ns2 string
+	if len(raw) > 0 && raw[0] != nil {
+		ns1 = raw[0].(string)
+	}
+	if len(raw) > 1 && raw[1] != nil {
+		ns2 = raw[1].(string)
+	}
+	return ns1, ns2
+}
+
+func flattenVPSNameservers(vm *VirtualMachine) []string {
+	nameservers := []string{}
+	for _, ns := range []string{vm.NS1, vm.NS2} {
+		if ns != "" {
+			nameservers = append(nameservers, ns)
+		}
+	}
+	return nameservers
+}
+
+// resourceHostingerVPSCustomizeDiff checks plan, template_id and
+// data_center_id against the API so that typos fail at plan time instead of
+// half way through an apply.
+func resourceHostingerVPSCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*HostingerClient)
+	if !ok || client == nil {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("plan", "template_id", "data_center_id") {
+		return nil
+	}
+	// Values coming from other resources are checked on a later plan
+	if !d.NewValueKnown("plan") || !d.NewValueKnown("template_id") || !d.NewValueKnown("data_center_id") {
+		return nil
+	}
+
+	if d.Id() == "" || d.HasChange("plan") {
+		if err := validateVPSPlan(client, d.Get("plan").(string)); err != nil {
+			return err
+		}
+	}
+	return validateVPSTemplateAndDataCenter(client, d.Get("template_id").(int), d.Get("data_center_id").(int))
+}
+
+// postInstallScriptLookup is the cached result of a post-install script lookup.
+type postInstallScriptLookup struct {
+	hash string
+	err  error
+}
+
+// postInstallScriptHashForPlan returns the content hash of the script, looking
+// it up once per run for all the VPSs that use it. A failed lookup is logged
+// once and its error returned to every caller.
+func (c *HostingerClient) postInstallScriptHashForPlan(scriptID int) (string, error) {
+	if v, ok := c.scriptHashes.Load(scriptID); ok {
+		lookup := v.(postInstallScriptLookup)
+		return lookup.hash, lookup.err
+	}
+
+	var lookup postInstallScriptLookup
+	script, err := c.GetPostInstallScript(scriptID)
+	if err != nil {
+		lookup.err = err
+	} else {
+		lookup.hash = postInstallScriptHash(script.Content)
+	}
+	if v, loaded := c.scriptHashes.LoadOrStore(scriptID, lookup); loaded {
+		lookup = v.(postInstallScriptLookup)
+	} else if err != nil {
+		log.Printf("[WARN] Failed to read post-install script %d, changes to its content are not detected in this plan: %s", scriptID, err)
+	}
+	return lookup.hash, lookup.err
+}
+
+// warnVPSScriptLookup reports, as a warning, that the post-install script of
+// a VPS with reinstall_on_script_change could not be read, so a change to its
+// content goes undetected. The lookup is shared with the plan that follows.
+func warnVPSScriptLookup(client *HostingerClient, d *schema.ResourceData) diag.Diagnostics {
+	scriptID := d.Get("post_install_script_id").(int)
+	if scriptID == 0 || !d.Get("reinstall_on_script_change").(bool) {
+		return nil
+	}
+	if _, err := client.postInstallScriptHashForPlan(scriptID); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to read post-install script %d", scriptID),
+			Detail:   fmt.Sprintf("Changes to the content of the script cannot be checked, so VPS %s is not reinstalled for them until the script can be read again: %s", d.Id(), err),
+		}}
+	}
+	return nil
+}
+
+// resourceHostingerVPSScriptCustomizeDiff plans a reinstall when
+// reinstall_on_script_change is set and the post-install script changed. The
+// script hash comes from configuration when given, otherwise from the API.
+func resourceHostingerVPSScriptCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("reinstall_on_script_change").(bool) {
+		return nil
+	}
+	client, ok := m.(*HostingerClient)
+	if !ok || client == nil {
+		return nil
+	}
+
+	if raw := d.GetRawConfig(); raw.IsNull() || raw.GetAttr("post_install_script_hash").IsNull() {
+		if !d.NewValueKnown("post_install_script_id") {
+			return d.SetNewComputed("post_install_script_hash")
+		}
+		old, _ := d.GetChange("post_install_script_hash")
+		hash := ""
+		if scriptID := d.Get("post_install_script_id").(int); scriptID != 0 {
+			var err error
+			if hash, err = client.postInstallScriptHashForPlan(scriptID); err != nil {
+				// Keep the stored hash rather than fail the plan, the refresh
+				// before it has warned about the failed lookup
+				hash = old.(string)
+			}
+		}
+		if old.(string) != hash {
+			if err := d.SetNew("post_install_script_hash", hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	if vpsScriptReinstallPlanned(d) {
+		log.Printf("[WARN] The post-install script of VPS %s changed, it will be reinstalled and its disk wiped", d.Id())
+	}
+	return nil
+}
+
+// vpsChange is the part of schema.ResourceData and schema.ResourceDiff used
+// to inspect planned changes.
+type vpsChange interface {
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// vpsScriptReinstallPlanned reports whether the post-install script changed in
+// a way that reinstalls the VPS. Enabling reinstall_on_script_change, or
+// recording the first hash, is bookkeeping only.
+func vpsScriptReinstallPlanned(d vpsChange) bool {
+	if !d.Get("reinstall_on_script_change").(bool) || d.HasChange("reinstall_on_script_change") {
+		return false
+	}
+	if d.HasChange("post_install_script_id") {
+		return true
+	}
+	oldHash, _ := d.GetChange("post_install_script_hash")
+	return oldHash.(string) != "" && d.HasChange("post_install_script_hash")
+}
+
+func warnVPSReinstallOnScriptChange(v interface{}, path cty.Path) diag.Diagnostics {
+	if enabled, ok := v.(bool); !ok || !enabled {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "Post-install script changes reinstall the VPS",
+		Detail:        "With reinstall_on_script_change enabled, changing post_install_script_id or the script content reinstalls the VPS, which wipes its disk.",
+		AttributePath: path,
+	}}
+}
+
+func validateVPSPlan(client *HostingerClient, plan string) error {
+	plans, err := client.ListPlanIDs()
+	if err != nil {
+		return fmt.Errorf("failed to validate plan: %w", err)
+	}
+	for _, p := range plans {
+		if p == plan {
+			return nil
+		}
+	}
+
+	if match := closestMatch(plan, plans); match != "" {
+		return fmt.Errorf("invalid plan %q, did you mean %s?", plan, match)
+	}
+	return fmt.Errorf("invalid plan %q, see the hostinger_vps_plans data source for available plans", plan)
+}
+
+func validateVPSTemplateAndDataCenter(client *HostingerClient, templateID, dataCenterID int) error {
+	datacenters, err := client.ListDataCenters()
+	if err != nil {
+		return fmt.Errorf("failed to validate data_center_id: %w", err)
+	}
+	dcNames := make(map[int]string, len(datacenters))
+	for _, dc := range datacenters {
+		dcNames[dc.ID] = dc.City
+	}
+	if _, ok := dcNames[dataCenterID]; !ok {
+		return invalidIDError("data_center_id", dataCenterID, dcNames)
+	}
+
+	templates, err := client.ListTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to validate template_id: %w", err)
+	}
+	templateNames := make(map[int]string, len(templates))
+	for _, t := range templates {
+		templateNames[t.ID] = t.Name
+	}
+	if _, ok := templateNames[templateID]; !ok {
+		return invalidIDError("template_id", templateID, templateNames)
+	}
+	return nil
+}
+
+// invalidIDError builds the error for an unknown numeric ID, listing the known
+// IDs with their names.
+func invalidIDError(attr string, id int, names map[int]string) error {
+	ids := make([]int, 0, len(names))
+	for k := range names {
+		ids = append(ids, k)
+	}
+	sort.Ints(ids)
+
+	valid := make([]string, len(ids))
+	for i, k := range ids {
+		valid[i] = fmt.Sprintf("%d (%s)", k, names[k])
+	}
+	return fmt.Errorf("invalid %s %d, valid values: %s", attr, id, strings.Join(valid, ", "))
+}
+
+// findInitialVirtualMachine returns the ID of a VPS in the initial state whose
+// subscription is for plan, that is not bound to another data center and
+// that is not claimed yet.
+func findInitialVirtualMachine(client *HostingerClient, plan string, dataCenterID int, claimed map[int]bool) (int, error) {
+	vms, err := client.GetVirtualMachines()
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range vms {
+		vm := &vms[i]
+		vm.resolveIDs()
+		if vm.State != vpsStateInitial || claimed[vm.ID] {
+			continue
+		}
+		if vm.DataCenterID != 0 && vm.DataCenterID != dataCenterID {
+			continue
+		}
+		ok, err := subscriptionMatchesPlan(client, vm.SubscriptionID, plan)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check the plan of VPS %d: %w", vm.ID, err)
+		}
+		if !ok {
+			continue
+		}
+		return vm.ID, nil
+	}
+	return 0, fmt.Errorf("no VPS in the %q state found for plan %s in data center %d", vpsStateInitial, plan, dataCenterID)
+}
+
+// subscriptionMatchesPlan reports whether the subscription was bought for plan.
+func subscriptionMatchesPlan(client *HostingerClient, subscriptionID, plan string) (bool, error) {
+	if subscriptionID == "" {
+		return false, nil
+	}
+	sub, err := client.GetSubscriptionDetails(subscriptionID)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(sub.ItemID, plan) || strings.EqualFold(sub.Plan, plan), nil
+}
+
+// setupInitialVirtualMachine runs the setup flow on an existing VPS in the
+// initial state.
+func setupInitialVirtualMachine(client *HostingerClient, vmID int, plan string, setup PurchaseVPSSetup) diag.Diagnostics {
+	vm, err := client.GetVirtualMachine(vmID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to fetch VPS details (ID %d): %w", vmID, err))
+	}
+	if vm.State != vpsStateInitial {
+		return diag.Errorf("VPS %d is in state %q, only a VPS in the %q state can be set up", vmID, vm.State, vpsStateInitial)
+	}
+	if vm.SubscriptionID != "" {
+		ok, err := subscriptionMatchesPlan(client, vm.SubscriptionID, plan)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to check the plan of VPS %d: %w", vmID, err))
+		}
+		if !ok {
+			return diag.Errorf("VPS %d was not purchased with plan %s", vmID, plan)
+		}
+	}
+
+	_, err = client.SetupVirtualMachine(vmID, SetupRequest{
+		DataCenterID:        setup.DataCenterID,
+		TemplateID:          setup.TemplateID,
+		Password:            setup.Password,
+		Hostname:            setup.Hostname,
+		PostInstallScriptID: setup.PostInstallScriptID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set up VPS %d: %w", vmID, err))
+	}
+	return nil
+}
+
+// setVPSMalwareScanner installs or uninstalls the Monarx malware scanner and
+// waits for the action to complete.
+func setVPSMalwareScanner(ctx context.Context, client *HostingerClient, vmID int, enabled bool, timeout time.Duration) error {
+	var action *Action
+	var err error
+	if enabled {
+		action, err = client.InstallMalwareScanner(vmID)
+	} else {
+		action, err = client.UninstallMalwareScanner(vmID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update malware scanner: %w", err)
+	}
+
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, timeout); err != nil {
+		return fmt.Errorf("error waiting for malware scanner on VPS %d: %w", vmID, err)
+	}
+	return nil
+}
+
+// waitForVPSState polls the VPS until it reaches one of the target states.
+// It gives up early if the VPS ends up in the `error` state.
+func waitForVPSState(ctx context.Context, client *HostingerClient, vmID int, timeout time.Duration, targets ...string) (*VirtualMachine, error) {
+	var vm *VirtualMachine
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		current, err := client.GetVirtualMachine(vmID)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		vm = current
+
+		for _, target := range targets {
+			if current.State == target {
+				return nil
+			}
+		}
+		if current.State == "error" {
+			return retry.NonRetryableError(fmt.Errorf("VPS %d entered the error state", vmID))
+		}
+		return retry.RetryableError(fmt.Errorf("VPS %d is %s, waiting for %s", vmID, current.State, strings.Join(targets, " or ")))
+	})
+	return vm, err
+}
+
+// waitForVPSAction polls an action started on the VPS until it succeeds.
+func waitForVPSAction(ctx context.Context, client *HostingerClient, vmID, actionID int, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		action, err := client.GetAction(vmID, actionID)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		switch action.State {
+		case "success":
+			return nil
+		case "error":
+			return retry.NonRetryableError(fmt.Errorf("action %s (%d) on VPS %d failed", action.Name, actionID, vmID))
+		default:
+			return retry.RetryableError(fmt.Errorf("action %s (%d) on VPS %d is %s", action.Name, actionID, vmID, action.State))
+		}
+	})
+}
+
+// vpsRootPassword returns the configured root password, taken either from
+// `password` or from the write-only `password_wo`, or nil when neither is set.
+func vpsRootPassword(d *schema.ResourceData) (*string, diag.Diagnostics) {
+	return configuredPassword(d, "password", "password_wo")
+}
+
+// configuredPassword reads a password that can be given either as a regular
+// attribute or as a write-only one. Write-only values never reach state, so
+// they have to be taken from the raw configuration.
+func configuredPassword(d *schema.ResourceData, key, writeOnlyKey string) (*string, diag.Diagnostics) {
+	if v, ok := d.GetOk(key); ok {
+		pw := v.(string)
+		return &pw, nil
+	}
+
+	raw, diags := d.GetRawConfigAt(cty.GetAttrPath(writeOnlyKey))
+	if diags.HasError() {
+		return nil, diags
+	}
+	if raw.IsKnown() && !raw.IsNull() && raw.Type() == cty.String && raw.AsString() != "" {
+		pw := raw.AsString()
+		return &pw, nil
+	}
+	return nil, nil
+}
+
+// validateVPSPassword enforces the root password rules of the Hostinger API:
+// 8 to 100 characters with at least one lowercase letter, one uppercase
+// letter and one digit.
+func validateVPSPassword(i interface{}, k string) ([]string, []error) {
+	pw, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	var errs []error
+	if len(pw) < 8 || len(pw) > 100 {
+		errs = append(errs, fmt.Errorf("%s must be between 8 and 100 characters long", k))
+	}
+
+	var hasLower, hasUpper, hasDigit bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLower || !hasUpper || !hasDigit {
+		errs = append(errs, fmt.Errorf("%s must contain at least one lowercase letter, one uppercase letter and one digit", k))
+	}
+	return nil, errs
+}
@@ -0,0 +1,816 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceHostingerVPS_Schema(t *testing.T) {
+	resource := resourceHostingerVPS()
+
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	if resource.Schema["password"].ForceNew {
+		t.Errorf("expected password to be updatable in place")
+	}
+	if !resource.Schema["password_wo"].WriteOnly {
+		t.Errorf("expected password_wo to be write-only")
+	}
+}
+
+func TestValidateVPSPassword(t *testing.T) {
+	cases := map[string]bool{
+		"SecureP4ssword": true,
+		"Abcdefg1":       true,
+		"Short1A":        false,
+		"alllowercase1":  false,
+		"ALLUPPERCASE1":  false,
+		"NoDigitsHere":   false,
+	}
+
+	for pw, valid := range cases {
+		_, errs := validateVPSPassword(pw, "password")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", pw, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", pw)
+		}
+	}
+}
+
+func TestSetRootPassword(t *testing.T) {
+	var got map[string]string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/api/vps/v1/virtual-machines/42/root-password" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	if err := client.SetRootPassword(42, "SecureP4ssword"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got["password"] != "SecureP4ssword" {
+		t.Errorf("expected password to be sent, got %v", got)
+	}
+}
+
+func TestResourceHostingerVPSDelete_OnDestroy(t *testing.T) {
+	var calls []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_, _ = w.Write([]byte(`{"id": 42, "subscription_id": "sub-1"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/billing/v1/subscriptions/sub-1/auto-renewal/disable":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "DELETE" && r.URL.Path == "/api/billing/v1/subscriptions/sub-1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	cases := []struct {
+		name       string
+		raw        map[string]interface{}
+		wantErr    bool
+		wantCalls  []string
+		wantGoneID bool
+	}{
+		{
+			name:      "protected",
+			raw:       map[string]interface{}{"deletion_protection": true},
+			wantErr:   true,
+			wantCalls: nil,
+		},
+		{
+			name:       "forget",
+			raw:        map[string]interface{}{"on_destroy": "forget"},
+			wantCalls:  nil,
+			wantGoneID: true,
+		},
+		{
+			name:       "disable auto-renew",
+			raw:        map[string]interface{}{"on_destroy": "disable_auto_renew"},
+			wantCalls:  []string{"GET /api/vps/v1/virtual-machines/42", "PATCH /api/billing/v1/subscriptions/sub-1/auto-renewal/disable"},
+			wantGoneID: true,
+		},
+		{
+			name:       "cancel",
+			raw:        map[string]interface{}{},
+			wantCalls:  []string{"GET /api/vps/v1/virtual-machines/42", "DELETE /api/billing/v1/subscriptions/sub-1"},
+			wantGoneID: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, tc.raw)
+			d.SetId("42")
+			if err := d.Set("vps_id", 42); err != nil {
+				t.Fatalf("failed to set vps_id: %v", err)
+			}
+
+			diags := resourceHostingerVPSDelete(context.Background(), d, client)
+			if diags.HasError() != tc.wantErr {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(calls) != len(tc.wantCalls) {
+				t.Fatalf("expected calls %v, got %v", tc.wantCalls, calls)
+			}
+			for i := range calls {
+				if calls[i] != tc.wantCalls[i] {
+					t.Errorf("expected call %q, got %q", tc.wantCalls[i], calls[i])
+				}
+			}
+			if tc.wantGoneID && d.Id() != "" {
+				t.Errorf("expected resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestResourceHostingerVPSRead_Nameservers(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/vps/v1/virtual-machines/42" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id": 42, "state": "running", "ns1": "1.1.1.1", "ns2": "8.8.8.8"}`))
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, map[string]interface{}{})
+	d.SetId("42")
+
+	if diags := resourceHostingerVPSRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	nameservers := d.Get("nameservers").([]interface{})
+	if len(nameservers) != 2 || nameservers[0] != "1.1.1.1" || nameservers[1] != "8.8.8.8" {
+		t.Errorf("unexpected nameservers: %v", nameservers)
+	}
+}
+
+func TestResourceHostingerVPS_Nameservers(t *testing.T) {
+	state := "installing"
+	ns := map[string]string{}
+	var puts []map[string]string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case serveVPSCatalog(w, r):
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"virtual_machine": map[string]interface{}{"id": 42, "state": state}})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			current := state
+			state = "running"
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "state": current, "ns1": ns["ns1"], "ns2": ns["ns2"]})
+		case r.Method == "PUT" && r.URL.Path == "/api/vps/v1/virtual-machines/42/nameservers":
+			if state != "running" {
+				t.Fatalf("nameservers set while the VPS is %s", state)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode nameservers request: %v", err)
+			}
+			ns = body
+			puts = append(puts, body)
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/monarx":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPS()
+	config := map[string]interface{}{
+		"plan":           "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id": 9,
+		"template_id":    1077,
+		"password":       "SecureP4ssword",
+		"nameservers":    []interface{}{"1.1.1.1", "8.8.8.8"},
+	}
+	apply := func(s *terraform.InstanceState) *terraform.InstanceState {
+		t.Helper()
+		diff, err := resource.Diff(context.Background(), s, terraform.NewResourceConfigRaw(config), client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if diff == nil {
+			return s
+		}
+		newState, diags := resource.Apply(context.Background(), s, diff, client)
+		if diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		return newState
+	}
+
+	s := apply(nil)
+	if len(puts) != 1 || puts[0]["ns1"] != "1.1.1.1" || puts[0]["ns2"] != "8.8.8.8" {
+		t.Fatalf("unexpected nameservers requests on create: %v", puts)
+	}
+
+	config["nameservers"] = []interface{}{"9.9.9.9"}
+	s = apply(s)
+	if len(puts) != 2 || puts[1]["ns1"] != "9.9.9.9" || puts[1]["ns2"] != "" {
+		t.Fatalf("unexpected nameservers requests on update: %v", puts)
+	}
+	if s.Attributes["nameservers.#"] != "1" || s.Attributes["nameservers.0"] != "9.9.9.9" {
+		t.Errorf("unexpected nameservers in state: %v", s.Attributes)
+	}
+
+	// Removing the argument keeps the current resolvers
+	delete(config, "nameservers")
+	apply(s)
+	if len(puts) != 2 {
+		t.Errorf("expected no nameservers request after removal, got %v", puts)
+	}
+}
+
+func TestResourceHostingerVPSRead_MalwareScanner(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		wantLookup  bool
+		wantEnabled bool
+	}{
+		{name: "not managed", config: map[string]interface{}{}},
+		{name: "managed", config: map[string]interface{}{"malware_scanner_enabled": false}, wantLookup: true, wantEnabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookedUp := false
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+					_, _ = w.Write([]byte(`{"id": 42, "state": "running"}`))
+				case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/monarx":
+					lookedUp = true
+					_, _ = w.Write([]byte(`{"records": 3, "malicious": 1, "compromised": 0, "scanned_files": 1200}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer mockServer.Close()
+
+			client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+			d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, tt.config)
+			d.SetId("42")
+			if diags := resourceHostingerVPSRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("read failed: %v", diags)
+			}
+
+			if lookedUp != tt.wantLookup {
+				t.Errorf("expected the scanner lookup to be %v, got %v", tt.wantLookup, lookedUp)
+			}
+			if got := d.Get("malware_scanner_enabled").(bool); got != tt.wantEnabled {
+				t.Errorf("expected malware_scanner_enabled to be %v, got %v", tt.wantEnabled, got)
+			}
+		})
+	}
+}
+
+func TestResourceHostingerVPSRead_ComputedAttributes(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_, _ = w.Write([]byte(`{"id": 42, "state": "running", "subscription_id": "sub-42",
+				"cpus": 2, "memory": 8192, "disk": 102400, "bandwidth": 8192000, "created_at": "2026-01-05T10:00:00Z",
+				"template": {"id": 1077, "name": "Ubuntu 24.04"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/billing/v1/subscriptions/sub-42":
+			_, _ = w.Write([]byte(`{"id": "sub-42", "expires_at": "2027-01-05T10:00:00Z"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, map[string]interface{}{})
+	d.SetId("42")
+
+	if diags := resourceHostingerVPSRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"cpus":            2,
+		"memory_mb":       8192,
+		"disk_gb":         100,
+		"bandwidth":       8192000,
+		"os_name":         "Ubuntu 24.04",
+		"subscription_id": "sub-42",
+		"created_at":      "2026-01-05T10:00:00Z",
+		"expires_at":      "2027-01-05T10:00:00Z",
+	}
+	for k, want := range expected {
+		if got := d.Get(k); got != want {
+			t.Errorf("expected %s to be %v, got %v", k, want, got)
+		}
+	}
+}
+
+func TestResourceHostingerVPSCreate_AdoptInitial(t *testing.T) {
+	var setup map[string]interface{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case serveVPSCatalog(w, r):
+		case r.URL.Path == "/api/vps/v1/virtual-machines":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "state": "running", "subscription_id": "sub-1"},
+				{"id": 2, "state": "initial", "subscription_id": "sub-2"},
+				{"id": 3, "state": "initial", "subscription_id": "sub-3"}
+			]`))
+		case r.URL.Path == "/api/billing/v1/subscriptions/sub-2":
+			_, _ = w.Write([]byte(`{"id": "sub-2", "item_id": "hostingercom-vps-kvm8-usd-1m"}`))
+		case r.URL.Path == "/api/billing/v1/subscriptions/sub-3":
+			_, _ = w.Write([]byte(`{"id": "sub-3", "item_id": "hostingercom-vps-kvm2-usd-1m"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/3":
+			state := "initial"
+			if setup != nil {
+				state = "running"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 3, "state": state, "hostname": "adopted.example.com"})
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/3/setup":
+			if err := json.NewDecoder(r.Body).Decode(&setup); err != nil {
+				t.Fatalf("failed to decode setup request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 3, "state": "installing"})
+		case r.URL.Path == "/api/vps/v1/virtual-machines/3/monarx":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, map[string]interface{}{
+		"plan":                   "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id":         9,
+		"template_id":            1077,
+		"hostname":               "adopted.example.com",
+		"password":               "Secr3tPassw0rd",
+		"post_install_script_id": 5,
+		"adopt_initial":          true,
+	})
+
+	if diags := resourceHostingerVPSCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	if d.Id() != "3" {
+		t.Errorf("expected VPS 3 to be adopted, got ID %q", d.Id())
+	}
+	if setup["template_id"] != float64(1077) || setup["data_center_id"] != float64(9) ||
+		setup["hostname"] != "adopted.example.com" || setup["password"] != "Secr3tPassw0rd" || setup["post_install_script_id"] != float64(5) {
+		t.Errorf("unexpected setup request: %v", setup)
+	}
+}
+
+func TestResourceHostingerVPSCreate_ValidatesUnknownValues(t *testing.T) {
+	mockServer := newVPSCatalogMockServer(t)
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	// A template_id taken from another resource is only known at apply
+	d := schema.TestResourceDataRaw(t, resourceHostingerVPS().Schema, map[string]interface{}{
+		"plan":           "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id": 9,
+		"template_id":    1003,
+	})
+	diags := resourceHostingerVPSCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "invalid template_id 1003") {
+		t.Fatalf("expected the template to be rejected before purchase, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no VPS to be created, got ID %q", d.Id())
+	}
+}
+
+func TestSetupInitialVirtualMachine_NotInitial(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vps/v1/virtual-machines/1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "state": "running", "subscription_id": "sub-1"}`))
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	diags := setupInitialVirtualMachine(client, 1, "hostingercom-vps-kvm2-usd-1m", PurchaseVPSSetup{DataCenterID: 9, TemplateID: 1077})
+	if !diags.HasError() {
+		t.Fatal("expected an error when the VPS is not in the initial state")
+	}
+}
+
+func TestAdoptInitialVirtualMachine_BillingError(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/vps/v1/virtual-machines":
+			_, _ = w.Write([]byte(`[{"id": 2, "state": "initial", "subscription_id": "sub-2"}]`))
+		case "/api/vps/v1/virtual-machines/2":
+			_, _ = w.Write([]byte(`{"id": 2, "state": "initial", "subscription_id": "sub-2"}`))
+		case "/api/billing/v1/subscriptions/sub-2":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	// A billing outage is reported as such, not as a missing VPS
+	_, err := findInitialVirtualMachine(client, "hostingercom-vps-kvm2-usd-1m", 9, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to check the plan of VPS 2") {
+		t.Errorf("expected the billing error, got %v", err)
+	}
+
+	diags := setupInitialVirtualMachine(client, 2, "hostingercom-vps-kvm2-usd-1m", PurchaseVPSSetup{DataCenterID: 9, TemplateID: 1077})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "failed to check the plan of VPS 2") {
+		t.Errorf("expected setup to stop on the billing error, got %v", diags)
+	}
+}
+
+func TestClaimInitialVirtualMachine_Concurrent(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/vps/v1/virtual-machines":
+			_, _ = w.Write([]byte(`[
+				{"id": 2, "state": "initial", "subscription_id": "sub-2"},
+				{"id": 3, "state": "initial", "subscription_id": "sub-3"}
+			]`))
+		case "/api/billing/v1/subscriptions/sub-2", "/api/billing/v1/subscriptions/sub-3":
+			_, _ = w.Write([]byte(`{"item_id": "hostingercom-vps-kvm2-usd-1m"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+	claim := func() (int, error) {
+		return client.claimInitialVirtualMachine(func(claimed map[int]bool) (int, error) {
+			return findInitialVirtualMachine(client, "hostingercom-vps-kvm2-usd-1m", 9, claimed)
+		})
+	}
+
+	// Both VPSs are still initial, as their setup has not started yet
+	var wg sync.WaitGroup
+	ids := make([]int, 2)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := claim()
+			if err != nil {
+				t.Errorf("claim failed: %v", err)
+			}
+			ids[i] = id
+		}(i)
+	}
+	wg.Wait()
+	if ids[0] == ids[1] {
+		t.Fatalf("expected two resources to adopt different VPSs, both got %d", ids[0])
+	}
+
+	if _, err := claim(); err == nil {
+		t.Error("expected no VPS to be left once both are claimed")
+	}
+
+	client.releaseInitialVirtualMachine(ids[0])
+	if id, err := claim(); err != nil || id != ids[0] {
+		t.Errorf("expected a released VPS to be claimable again, got %d, %v", id, err)
+	}
+}
+
+func newVPSCatalogMockServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !serveVPSCatalog(w, r) {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+// serveVPSCatalog answers the plan, template and data center lookups used to
+// validate a VPS and reports whether the request was one of them.
+func serveVPSCatalog(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Path {
+	case "/api/billing/v1/catalog":
+		_, _ = w.Write([]byte(`[{"prices": [{"id": "hostingercom-vps-kvm2-usd-1m"}, {"id": "hostingercom-vps-kvm4-usd-1m"}]}]`))
+	case "/api/vps/v1/templates":
+		_, _ = w.Write([]byte(`[{"id": 1002, "name": "Debian 11"}, {"id": 1077, "name": "Ubuntu 24.04"}, {"id": 1150, "name": "Windows Server 2022"}]`))
+	case "/api/vps/v1/data-centers":
+		_, _ = w.Write([]byte(`[{"id": 9, "name": "nl", "city": "Amsterdam"}, {"id": 17, "name": "us", "city": "Boston"}]`))
+	default:
+		return false
+	}
+	return true
+}
+
+func TestResourceHostingerVPSCustomizeDiff(t *testing.T) {
+	mockServer := newVPSCatalogMockServer(t)
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: map[string]interface{}{"plan": "hostingercom-vps-kvm2-usd-1m", "data_center_id": 9, "template_id": 1077},
+		},
+		{
+			name:    "plan typo",
+			config:  map[string]interface{}{"plan": "hostingercom-vps-kvm2-usd-1mo", "data_center_id": 9, "template_id": 1077},
+			wantErr: `invalid plan "hostingercom-vps-kvm2-usd-1mo", did you mean hostingercom-vps-kvm2-usd-1m?`,
+		},
+		{
+			name:    "unknown plan",
+			config:  map[string]interface{}{"plan": "business", "data_center_id": 9, "template_id": 1077},
+			wantErr: `invalid plan "business", see the hostinger_vps_plans data source for available plans`,
+		},
+		{
+			name:    "unknown template",
+			config:  map[string]interface{}{"plan": "hostingercom-vps-kvm2-usd-1m", "data_center_id": 9, "template_id": 1003},
+			wantErr: "invalid template_id 1003, valid values: 1002 (Debian 11), 1077 (Ubuntu 24.04), 1150 (Windows Server 2022)",
+		},
+		{
+			name:    "unknown data center",
+			config:  map[string]interface{}{"plan": "hostingercom-vps-kvm2-usd-1m", "data_center_id": 400, "template_id": 1077},
+			wantErr: "invalid data_center_id 400, valid values: 9 (Amsterdam), 17 (Boston)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resourceHostingerVPS().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), client)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResourceHostingerVPS_ReinstallOnScriptChange(t *testing.T) {
+	var recreate map[string]interface{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/post-install-scripts/5":
+			_, _ = w.Write([]byte(`{"id": 5, "name": "bootstrap", "content": "#!/bin/bash\necho v2\n"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/recreate":
+			if err := json.NewDecoder(r.Body).Decode(&recreate); err != nil {
+				t.Fatalf("failed to decode recreate request: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"id": 1, "state": "sent"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_, _ = w.Write([]byte(`{"id": 42, "state": "running"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/monarx":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	config := map[string]interface{}{
+		"plan":                       "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id":             9,
+		"template_id":                1077,
+		"password":                   "SecureP4ssword",
+		"post_install_script_id":     5,
+		"reinstall_on_script_change": true,
+	}
+	state := func(hash, reinstall string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "42",
+			Attributes: map[string]string{
+				"id":                         "42",
+				"vps_id":                     "42",
+				"plan":                       "hostingercom-vps-kvm2-usd-1m",
+				"data_center_id":             "9",
+				"template_id":                "1077",
+				"password":                   "SecureP4ssword",
+				"post_install_script_id":     "5",
+				"post_install_script_hash":   hash,
+				"reinstall_on_script_change": reinstall,
+				"deletion_protection":        "false",
+				"on_destroy":                 "cancel",
+			},
+		}
+	}
+	newHash := postInstallScriptHash("#!/bin/bash\necho v2")
+
+	t.Run("unchanged script", func(t *testing.T) {
+		diff, err := resourceHostingerVPS().Diff(context.Background(), state(newHash, "true"), terraform.NewResourceConfigRaw(config), client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if diff != nil && diff.Attributes["post_install_script_hash"] != nil {
+			t.Errorf("expected no change to post_install_script_hash, got %+v", diff.Attributes["post_install_script_hash"])
+		}
+	})
+
+	t.Run("enabling records the hash without reinstalling", func(t *testing.T) {
+		resource := resourceHostingerVPS()
+		s := state("", "false")
+		diff, err := resource.Diff(context.Background(), s, terraform.NewResourceConfigRaw(config), client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		recreate = nil
+		if _, diags := resource.Apply(context.Background(), s, diff, client); diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		if recreate != nil {
+			t.Errorf("expected no reinstall when enabling the option, got %v", recreate)
+		}
+	})
+
+	t.Run("changed script content reinstalls", func(t *testing.T) {
+		resource := resourceHostingerVPS()
+		s := state(postInstallScriptHash("#!/bin/bash\necho v1"), "true")
+		diff, err := resource.Diff(context.Background(), s, terraform.NewResourceConfigRaw(config), client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if attr := diff.Attributes["post_install_script_hash"]; attr == nil || attr.New != newHash {
+			t.Fatalf("expected post_install_script_hash to change to %s, got %+v", newHash, attr)
+		}
+
+		recreate = nil
+		newState, diags := resource.Apply(context.Background(), s, diff, client)
+		if diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		if recreate["template_id"] != float64(1077) || recreate["post_install_script_id"] != float64(5) || recreate["password"] != "SecureP4ssword" {
+			t.Errorf("unexpected recreate request: %v", recreate)
+		}
+		if newState.Attributes["post_install_script_hash"] != newHash {
+			t.Errorf("expected new hash in state, got %q", newState.Attributes["post_install_script_hash"])
+		}
+	})
+}
+
+func TestResourceHostingerVPS_ScriptLookupFailure(t *testing.T) {
+	var lookups int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/vps/v1/post-install-scripts/5":
+			lookups++
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "internal error"}`))
+		case "/api/vps/v1/virtual-machines/42":
+			_, _ = w.Write([]byte(`{"id": 42, "state": "running", "template": {"id": 1077}, "data_center_id": 9}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	oldHash := postInstallScriptHash("#!/bin/bash\necho v1")
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":                         "42",
+			"vps_id":                     "42",
+			"plan":                       "hostingercom-vps-kvm2-usd-1m",
+			"data_center_id":             "9",
+			"template_id":                "1077",
+			"post_install_script_id":     "5",
+			"post_install_script_hash":   oldHash,
+			"reinstall_on_script_change": "true",
+			"deletion_protection":        "false",
+			"on_destroy":                 "cancel",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"plan":                       "hostingercom-vps-kvm2-usd-1m",
+		"data_center_id":             9,
+		"template_id":                1077,
+		"post_install_script_id":     5,
+		"reinstall_on_script_change": true,
+	})
+
+	// The refresh warns that the script cannot be checked
+	diags := resourceHostingerVPSRead(context.Background(), resourceHostingerVPS().Data(state), client)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "post-install script 5") {
+		t.Fatalf("expected a warning about the failed lookup, got %v", diags)
+	}
+
+	// The plans of two VPSs using the same script reuse that lookup
+	for i := 0; i < 2; i++ {
+		diff, err := resourceHostingerVPS().Diff(context.Background(), state, config, client)
+		if err != nil {
+			t.Fatalf("expected a failed lookup not to fail the plan, got %v", err)
+		}
+		if diff != nil && diff.Attributes["post_install_script_hash"] != nil {
+			t.Errorf("expected the stored hash to be kept, got %+v", diff.Attributes["post_install_script_hash"])
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected the script to be looked up once, got %d lookups", lookups)
+	}
+}
+
+func TestWarnVPSReinstallOnScriptChange(t *testing.T) {
+	if diags := warnVPSReinstallOnScriptChange(false, nil); len(diags) != 0 {
+		t.Errorf("expected no warning when disabled, got %v", diags)
+	}
+	diags := warnVPSReinstallOnScriptChange(true, nil)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a single warning when enabled, got %v", diags)
+	}
+}
@@ -0,0 +1,227 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type Snapshot struct {
+	ID        int    `json:"id"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func resourceHostingerVPSSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostingerVPSSnapshotCreate,
+		ReadContext:   resourceHostingerVPSSnapshotRead,
+		UpdateContext: resourceHostingerVPSSnapshotUpdate,
+		DeleteContext: resourceHostingerVPSSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vps_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the VPS to snapshot. A VPS holds a single snapshot, so creating one replaces any existing snapshot.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"restore_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value that restores the VPS from this snapshot whenever it changes. Setting it where it was not set before, as after an import, only records it.",
+			},
+			"snapshot_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the snapshot.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the snapshot was taken.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the snapshot will be removed by Hostinger.",
+			},
+		},
+	}
+}
+
+func resourceHostingerVPSSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID := d.Get("vps_id").(int)
+
+	action, err := client.CreateSnapshot(vmID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create snapshot: %w", err))
+	}
+
+	// Tracked before waiting, so that a timeout does not orphan the snapshot
+	d.SetId(strconv.Itoa(vmID))
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for snapshot of VPS %d: %w", vmID, err))
+	}
+
+	return resourceHostingerVPSSnapshotRead(ctx, d, m)
+}
+
+func resourceHostingerVPSSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	snapshot, err := client.GetSnapshot(vmID)
+	if err != nil {
+		if err == ErrNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to read snapshot: %w", err))
+	}
+
+	// A newer snapshot taken outside Terraform replaced ours
+	if id, ok := d.GetOk("snapshot_id"); ok && id.(int) != snapshot.ID {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("vps_id", vmID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set vps_id: %w", err))
+	}
+	if err := d.Set("snapshot_id", snapshot.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set snapshot_id: %w", err))
+	}
+	if err := d.Set("created_at", snapshot.CreatedAt); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set created_at: %w", err))
+	}
+	if err := d.Set("expires_at", snapshot.ExpiresAt); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set expires_at: %w", err))
+	}
+	return nil
+}
+
+func resourceHostingerVPSSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, _ := strconv.Atoi(d.Id())
+
+	// A trigger that was not set before, as after an import, is only recorded.
+	// Restoring then would wipe the VPS for a value that never changed.
+	if old, _ := d.GetChange("restore_trigger"); old.(string) != "" && d.HasChange("restore_trigger") {
+		action, err := client.RestoreSnapshot(vmID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to restore snapshot: %w", err))
+		}
+
+		if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for snapshot restore of VPS %d: %w", vmID, err))
+		}
+		if _, err := waitForVPSState(ctx, client, vmID, d.Timeout(schema.TimeoutUpdate), vpsStateRunning); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for VPS %d to run after restore: %w", vmID, err))
+		}
+	}
+
+	return resourceHostingerVPSSnapshotRead(ctx, d, m)
+}
+
+func resourceHostingerVPSSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*HostingerClient)
+	vmID, _ := strconv.Atoi(d.Id())
+
+	action, err := client.DeleteSnapshot(vmID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete snapshot: %w", err))
+	}
+
+	if err := waitForVPSAction(ctx, client, vmID, action.ID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for snapshot deletion on VPS %d: %w", vmID, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// HostingerClient implementations:
+
+func (c *HostingerClient) GetSnapshot(vmID int) (*Snapshot, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/snapshot", c.BaseURL, vmID)
+	req, _ := http.NewRequest("GET", url, nil)
+	c.addStandardHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("read snapshot failed (HTTP %d): %s", resp.StatusCode, msg)
+	}
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	// The API answers with an empty object when the VPS has no snapshot
+	if snapshot.ID == 0 {
+		return nil, ErrNotFound
+	}
+	return &snapshot, nil
+}
+
+func (c *HostingerClient) CreateSnapshot(vmID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/snapshot", c.BaseURL, vmID)
+	action, err := c.doVPSAction("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) RestoreSnapshot(vmID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/snapshot/restore", c.BaseURL, vmID)
+	action, err := c.doVPSAction("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("restore snapshot failed: %w", err)
+	}
+	return action, nil
+}
+
+func (c *HostingerClient) DeleteSnapshot(vmID int) (*Action, error) {
+	url := fmt.Sprintf("%s/api/vps/v1/virtual-machines/%d/snapshot", c.BaseURL, vmID)
+	action, err := c.doVPSAction("DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete snapshot failed: %w", err)
+	}
+	return action, nil
+}
@@ -0,0 +1,181 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceHostingerVPSSnapshot_Lifecycle(t *testing.T) {
+	var snapshot *Snapshot
+	restored := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot":
+			snapshot = &Snapshot{ID: 9, CreatedAt: "2026-10-18T10:00:00Z", ExpiresAt: "2026-11-07T10:00:00Z"}
+			_ = json.NewEncoder(w).Encode(Action{ID: 100, Name: "snapshot_create", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot":
+			if snapshot == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(snapshot)
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot/restore":
+			restored = true
+			_ = json.NewEncoder(w).Encode(Action{ID: 101, Name: "snapshot_restore", State: "sent"})
+		case r.Method == "DELETE" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot":
+			snapshot = nil
+			_ = json.NewEncoder(w).Encode(Action{ID: 102, Name: "snapshot_delete", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/100",
+			r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/102":
+			_ = json.NewEncoder(w).Encode(Action{ID: 100, State: "success"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{
+		BaseURL:    mockServer.URL,
+		HTTPClient: http.DefaultClient,
+		Token:      "test-token",
+	}
+
+	resource := resourceHostingerVPSSnapshot()
+	if err := resource.InternalValidate(resource.Schema, true); err != nil {
+		t.Fatalf("schema validation failed: %s", err)
+	}
+
+	d := resource.TestResourceData()
+	_ = d.Set("vps_id", 42)
+
+	if diags := resourceHostingerVPSSnapshotCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if d.Get("snapshot_id") != 9 || d.Get("expires_at") != "2026-11-07T10:00:00Z" {
+		t.Errorf("unexpected attributes after create: %v %v", d.Get("snapshot_id"), d.Get("expires_at"))
+	}
+	if restored {
+		t.Errorf("creating a snapshot must not restore it")
+	}
+
+	// A newer snapshot replaces ours and drops it from state
+	snapshot = &Snapshot{ID: 10}
+	if diags := resourceHostingerVPSSnapshotRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected replaced snapshot to be removed from state")
+	}
+
+	d.SetId("42")
+	if diags := resourceHostingerVPSSnapshotDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if snapshot != nil || d.Id() != "" {
+		t.Errorf("expected snapshot to be deleted")
+	}
+}
+
+func TestResourceHostingerVPSSnapshot_RestoreTrigger(t *testing.T) {
+	restores := 0
+	actionPolled := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot":
+			_ = json.NewEncoder(w).Encode(Snapshot{ID: 9, CreatedAt: "2026-10-18T10:00:00Z", ExpiresAt: "2026-11-07T10:00:00Z"})
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot/restore":
+			restores++
+			_ = json.NewEncoder(w).Encode(Action{ID: 101, Name: "snapshot_restore", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/101":
+			actionPolled = true
+			_ = json.NewEncoder(w).Encode(Action{ID: 101, Name: "snapshot_restore", State: "success"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42":
+			_ = json.NewEncoder(w).Encode(VirtualMachine{ID: 42, State: "running"})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	// As imported, without a trigger
+	resource := resourceHostingerVPSSnapshot()
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":          "42",
+			"vps_id":      "42",
+			"snapshot_id": "9",
+		},
+	}
+	apply := func(trigger string) *terraform.InstanceState {
+		t.Helper()
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{"vps_id": 42, "restore_trigger": trigger})
+		diff, err := resource.Diff(context.Background(), state, cfg, client)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if diff == nil || diff.Empty() {
+			return state
+		}
+		if diff.RequiresNew() {
+			t.Fatalf("expected an in-place update, got %+v", diff.Attributes)
+		}
+		newState, diags := resource.Apply(context.Background(), state, diff, client)
+		if diags.HasError() {
+			t.Fatalf("apply failed: %v", diags)
+		}
+		return newState
+	}
+
+	// The first trigger is only recorded
+	state = apply("before-upgrade")
+	if restores != 0 || state.Attributes["restore_trigger"] != "before-upgrade" {
+		t.Fatalf("expected the first trigger to be recorded without a restore, got %d restores, state %v", restores, state.Attributes)
+	}
+
+	// An unchanged trigger does not restore
+	state = apply("before-upgrade")
+	if restores != 0 {
+		t.Fatalf("expected no restore for an unchanged trigger, got %d", restores)
+	}
+
+	state = apply("rollback-1")
+	if restores != 1 || !actionPolled {
+		t.Errorf("expected one restore waited on through its action, got %d restores, polled %v", restores, actionPolled)
+	}
+	if state.ID != "42" || state.Attributes["restore_trigger"] != "rollback-1" || state.Attributes["snapshot_id"] != "9" {
+		t.Errorf("unexpected state after restore: %v", state.Attributes)
+	}
+}
+
+func TestResourceHostingerVPSSnapshot_CreateTimeout(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/vps/v1/virtual-machines/42/snapshot":
+			_ = json.NewEncoder(w).Encode(Action{ID: 100, Name: "snapshot_create", State: "sent"})
+		case r.Method == "GET" && r.URL.Path == "/api/vps/v1/virtual-machines/42/actions/100":
+			_ = json.NewEncoder(w).Encode(Action{ID: 100, State: "error"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+	client := &HostingerClient{BaseURL: mockServer.URL, HTTPClient: http.DefaultClient, Token: "test-token"}
+
+	d := resourceHostingerVPSSnapshot().TestResourceData()
+	_ = d.Set("vps_id", 42)
+	if diags := resourceHostingerVPSSnapshotCreate(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("expected the failed snapshot action to be reported")
+	}
+	if d.Id() != "42" {
+		t.Errorf("expected the snapshot to stay tracked in state, got ID %q", d.Id())
+	}
+}
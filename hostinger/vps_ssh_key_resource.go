@@ -26,6 +26,14 @@ func resourceHostingerVPSSSHKey() *schema.Resource {
 		CreateContext: resourceHostingerVPSSSHKeyCreate,
 		ReadContext:   resourceHostingerVPSSSHKeyRead,
 		DeleteContext: resourceHostingerVPSSSHKeyDelete,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceHostingerVPSSSHKeyV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: numericIDStateUpgradeV0("hostinger_vps_ssh_key"),
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,